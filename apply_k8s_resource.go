@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"net/http"
+	"strconv"
+)
+
+const ApplyActivityType = "Apply"
+
+// ApplyK8sResourceHandler applies edited YAML of any resource with server-side apply,
+// NS is the namespace of namespaced objects which do not name one
+type ApplyK8sResourceHandler struct {
+	ID   string
+	Name string
+	NS   string
+}
+
+func (h *ApplyK8sResourceHandler) ServeHTTP(c echo.Context) error {
+	type applyType struct {
+		YAML   string `json:"yaml"`
+		DryRun bool   `json:"dry_run"`
+		Force  bool   `json:"force"`
+	}
+	type Response struct {
+		Kind      string          `json:"kind"`
+		Name      string          `json:"name"`
+		Namespace string          `json:"namespace"`
+		DryRun    bool            `json:"dry_run"`
+		Created   bool            `json:"created"`
+		Diff      string          `json:"diff"`
+		Message   string          `json:"message"`
+		Conflicts []ApplyConflict `json:"conflicts"`
+	}
+	var applyPost applyType
+	if err := c.Bind(&applyPost); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	object, err := ParseManifest(applyPost.YAML)
+	if err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+
+	config, errMsg, err := GetRestConfig(h.ID, h.Name, "ApplyK8sResourceHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "ApplyK8sResourceHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+	applier, err := NewApplier(config, clientset)
+	if err != nil {
+		logger.Warnf("Failed to create dynamic client when calling ApplyK8sResourceHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	response := Response{
+		Kind:      object.GetKind(),
+		Name:      object.GetName(),
+		DryRun:    applyPost.DryRun,
+		Conflicts: make([]ApplyConflict, 0),
+	}
+
+	login, role := SessionUser(c)
+	resource, err := applier.Resolve(object, h.NS, role)
+	response.Namespace = object.GetNamespace()
+	if err != nil {
+		response.Message = err.Error()
+		if errors.Is(err, ErrApplyForbidden) {
+			logger.Warnf("Denied applying %s %s to %q with role %s", object.GetKind(), object.GetName(), login, role)
+			return c.JSON(http.StatusForbidden, response)
+		}
+		if meta.IsNoMatchError(err) {
+			return c.JSON(http.StatusUnprocessableEntity, response)
+		}
+		logger.Warnf("Failed to resolve %s when calling ApplyK8sResourceHandler: %v", object.GetKind(), err)
+		return c.JSON(http.StatusInternalServerError, response)
+	}
+
+	// The namespace is the one of the object now, cluster-scoped objects have none
+	var audit *AuditEntry
+	if !applyPost.DryRun {
+		audit = StartAudit(c, "apply", AuditTarget{ClusterID: h.ID, ClusterName: h.Name, Namespace: object.GetNamespace(), Kind: object.GetKind(), Name: object.GetName()}, map[string]string{
+			"force": strconv.FormatBool(applyPost.Force),
+		})
+	}
+	result, err := Apply(context.Background(), resource, object, applyPost.Force, applyPost.DryRun)
+	if audit != nil {
+		audit.Finish(err)
+	}
+	if err != nil {
+		response.Message = err.Error()
+		if apierrors.IsConflict(err) {
+			// Either fields owned by other managers or a stale resourceVersion in the edited YAML
+			response.Conflicts = ApplyConflicts(err)
+			return c.JSON(http.StatusConflict, response)
+		}
+		if apierrors.IsInvalid(err) || apierrors.IsBadRequest(err) {
+			return c.JSON(http.StatusUnprocessableEntity, response)
+		}
+		logger.Warnf("Failed to apply %s when calling ApplyK8sResourceHandler: %v", object.GetKind(), err)
+		return c.JSON(http.StatusInternalServerError, response)
+	}
+	response.Created = result.Created
+
+	liveYAML, err := ObjectYAML(result.Live)
+	if err != nil {
+		logger.Warnf("Error marshaling to YAML when calling ApplyK8sResourceHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+	appliedYAML, err := ObjectYAML(result.Object)
+	if err != nil {
+		logger.Warnf("Error marshaling to YAML when calling ApplyK8sResourceHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+	response.Diff, err = UnifiedDiff(liveYAML, appliedYAML, "live", "applied")
+	if err != nil {
+		logger.Warnf("Failed to diff when calling ApplyK8sResourceHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	if !applyPost.DryRun {
+		action := "applied"
+		if result.Created {
+			action = "created"
+		}
+		LogActivityConsoleAdd(fmt.Sprintf("%s %s/%s %s", object.GetKind(), object.GetNamespace(), object.GetName(), action), ApplyActivityType)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
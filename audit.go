@@ -0,0 +1,67 @@
+package main
+
+import (
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+type AuditTarget struct {
+	ClusterID   string `bson:"cluster_id" json:"cluster_id"`
+	ClusterName string `bson:"cluster_name" json:"cluster_name"`
+	Namespace   string `bson:"namespace" json:"namespace"`
+	Kind        string `bson:"kind" json:"kind"`
+	Name        string `bson:"name" json:"name"`
+}
+
+type AuditEntry struct {
+	ID      primitive.ObjectID `bson:"_id" json:"id"`
+	Action  string             `bson:"action" json:"action"`
+	Login   string             `bson:"login" json:"login"`
+	Role    string             `bson:"role" json:"role"`
+	Remote  string             `bson:"remote" json:"remote"`
+	Target  AuditTarget        `bson:"target" json:"target"`
+	Details map[string]string  `bson:"details" json:"details"`
+	Start   time.Time          `bson:"start" json:"start"`
+	End     *time.Time         `bson:"end" json:"end"`
+	Result  string             `bson:"result" json:"result"`
+	Error   string             `bson:"error" json:"error"`
+}
+
+// StartAudit records that the session user started an action, the entry is closed by Finish.
+// Failing to write the audit log is logged but does not stop the action.
+func StartAudit(c echo.Context, action string, target AuditTarget, details map[string]string) *AuditEntry {
+	login, role := SessionUser(c)
+	entry := &AuditEntry{
+		ID:      primitive.NewObjectID(),
+		Action:  action,
+		Login:   login,
+		Role:    role,
+		Remote:  c.RealIP(),
+		Target:  target,
+		Details: details,
+		Start:   time.Now(),
+		Result:  "started",
+	}
+	if err := DBHelper.InsertOne(AuditCollection, entry); err != nil {
+		logger.Warnf("Failed to write audit entry for %s: %v", action, err)
+	}
+	return entry
+}
+
+// Finish stores the end time and the outcome of the audited action
+func (e *AuditEntry) Finish(err error) {
+	end := time.Now()
+	e.End = &end
+	e.Result = "succeeded"
+	if err != nil {
+		e.Result = "failed"
+		e.Error = err.Error()
+	}
+	update := bson.M{"$set": bson.M{"end": e.End, "result": e.Result, "error": e.Error}}
+	if err := DBHelper.UpdateOne(AuditCollection, BsonEquals("_id", e.ID), update); err != nil {
+		logger.Warnf("Failed to finish audit entry for %s: %v", e.Action, err)
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"net/http"
+)
+
+// CordonK8sNodeHandler marks a node unschedulable, or schedulable again when Unschedulable is false
+type CordonK8sNodeHandler struct {
+	ID            string
+	Name          string
+	Node          string
+	Unschedulable bool
+}
+
+func (h *CordonK8sNodeHandler) ServeHTTP(c echo.Context) error {
+	type Response struct {
+		Node          string `json:"node"`
+		Unschedulable bool   `json:"unschedulable"`
+		Changed       bool   `json:"changed"`
+	}
+	action := "uncordon"
+	if h.Unschedulable {
+		action = "cordon"
+	}
+
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "CordonK8sNodeHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	audit := StartAudit(c, action, AuditTarget{ClusterID: h.ID, ClusterName: h.Name, Kind: "Node", Name: h.Node}, nil)
+	changed, err := CordonNode(context.Background(), clientset, h.Node, h.Unschedulable)
+	audit.Finish(err)
+	if apierrors.IsNotFound(err) {
+		return c.String(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		logger.Warnf("Failed to %s node when calling CordonK8sNodeHandler: %v", action, err)
+		return c.String(http.StatusInternalServerError, err.Error())
+	}
+
+	if changed {
+		LogActivityConsoleAdd(fmt.Sprintf("Node %s %sed", h.Node, action), NodeActivityType)
+	}
+	return c.JSON(http.StatusOK, Response{
+		Node:          h.Node,
+		Unschedulable: h.Unschedulable,
+		Changed:       changed,
+	})
+}
@@ -134,7 +134,7 @@ func (dh *DatabaseHelper) FindGtLtLimit(collectionName string, field string, val
 	}
 
 	findOptions := options.Find()
-	findOptions.SetSort(bson.D{{field, -1}}).SetLimit(int64(limit))
+	findOptions.SetSort(bson.D{{Key: field, Value: -1}}).SetLimit(int64(limit))
 
 	cur, err := dh.db.Collection(collectionName).Find(context.TODO(), filter, findOptions)
 	if err != nil {
@@ -170,12 +170,30 @@ func (dh *DatabaseHelper) FindGtLtLimit(collectionName string, field string, val
 	return nil
 }
 
+func (dh *DatabaseHelper) Aggregate(collectionName string, pipeline mongo.Pipeline, results interface{}) error {
+	cur, err := dh.db.Collection(collectionName).Aggregate(context.TODO(), pipeline)
+	if err != nil {
+		return err
+	}
+	return cur.All(context.Background(), results)
+}
+
 func (dh *DatabaseHelper) InsertOne(collectionName string, data interface{}) error {
 	_, err := dh.db.Collection(collectionName).InsertOne(context.TODO(), data)
 	return err
 }
 
+func (dh *DatabaseHelper) InsertMany(collectionName string, data []interface{}) error {
+	_, err := dh.db.Collection(collectionName).InsertMany(context.TODO(), data)
+	return err
+}
+
 func (dh *DatabaseHelper) DeleteOne(collectionName string, filter bson.M) error {
 	_, err := dh.db.Collection(collectionName).DeleteOne(context.Background(), filter)
 	return err
 }
+
+func (dh *DatabaseHelper) UpdateOne(collectionName string, filter bson.M, update bson.M) error {
+	_, err := dh.db.Collection(collectionName).UpdateOne(context.Background(), filter, update)
+	return err
+}
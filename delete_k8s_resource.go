@@ -0,0 +1,263 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"net/http"
+	"strconv"
+)
+
+const DeleteActivityType = "Delete"
+
+// DeleteK8sResourceHandler deletes a pod or workload, Cascade is foreground, background or orphan
+// and DryRun only asks the API server to validate the request
+type DeleteK8sResourceHandler struct {
+	ID          string
+	Name        string
+	NS          string
+	Kind        string
+	Object      string
+	Cascade     string
+	GracePeriod string
+	DryRun      bool
+}
+
+type deletableKind struct {
+	get    func(ctx context.Context, clientset *kubernetes.Clientset, ns, name string) (metav1.Object, error)
+	delete func(ctx context.Context, clientset *kubernetes.Clientset, ns, name string, opts metav1.DeleteOptions) error
+}
+
+var deletableKinds = map[string]deletableKind{
+	"Pod": {
+		get: func(ctx context.Context, clientset *kubernetes.Clientset, ns, name string) (metav1.Object, error) {
+			return clientset.CoreV1().Pods(ns).Get(ctx, name, metav1.GetOptions{})
+		},
+		delete: func(ctx context.Context, clientset *kubernetes.Clientset, ns, name string, opts metav1.DeleteOptions) error {
+			return clientset.CoreV1().Pods(ns).Delete(ctx, name, opts)
+		},
+	},
+	"Deployment": {
+		get: func(ctx context.Context, clientset *kubernetes.Clientset, ns, name string) (metav1.Object, error) {
+			return clientset.AppsV1().Deployments(ns).Get(ctx, name, metav1.GetOptions{})
+		},
+		delete: func(ctx context.Context, clientset *kubernetes.Clientset, ns, name string, opts metav1.DeleteOptions) error {
+			return clientset.AppsV1().Deployments(ns).Delete(ctx, name, opts)
+		},
+	},
+	"ReplicaSet": {
+		get: func(ctx context.Context, clientset *kubernetes.Clientset, ns, name string) (metav1.Object, error) {
+			return clientset.AppsV1().ReplicaSets(ns).Get(ctx, name, metav1.GetOptions{})
+		},
+		delete: func(ctx context.Context, clientset *kubernetes.Clientset, ns, name string, opts metav1.DeleteOptions) error {
+			return clientset.AppsV1().ReplicaSets(ns).Delete(ctx, name, opts)
+		},
+	},
+	"StatefulSet": {
+		get: func(ctx context.Context, clientset *kubernetes.Clientset, ns, name string) (metav1.Object, error) {
+			return clientset.AppsV1().StatefulSets(ns).Get(ctx, name, metav1.GetOptions{})
+		},
+		delete: func(ctx context.Context, clientset *kubernetes.Clientset, ns, name string, opts metav1.DeleteOptions) error {
+			return clientset.AppsV1().StatefulSets(ns).Delete(ctx, name, opts)
+		},
+	},
+	"DaemonSet": {
+		get: func(ctx context.Context, clientset *kubernetes.Clientset, ns, name string) (metav1.Object, error) {
+			return clientset.AppsV1().DaemonSets(ns).Get(ctx, name, metav1.GetOptions{})
+		},
+		delete: func(ctx context.Context, clientset *kubernetes.Clientset, ns, name string, opts metav1.DeleteOptions) error {
+			return clientset.AppsV1().DaemonSets(ns).Delete(ctx, name, opts)
+		},
+	},
+	"Job": {
+		get: func(ctx context.Context, clientset *kubernetes.Clientset, ns, name string) (metav1.Object, error) {
+			return clientset.BatchV1().Jobs(ns).Get(ctx, name, metav1.GetOptions{})
+		},
+		delete: func(ctx context.Context, clientset *kubernetes.Clientset, ns, name string, opts metav1.DeleteOptions) error {
+			return clientset.BatchV1().Jobs(ns).Delete(ctx, name, opts)
+		},
+	},
+	"CronJob": {
+		get: func(ctx context.Context, clientset *kubernetes.Clientset, ns, name string) (metav1.Object, error) {
+			return clientset.BatchV1().CronJobs(ns).Get(ctx, name, metav1.GetOptions{})
+		},
+		delete: func(ctx context.Context, clientset *kubernetes.Clientset, ns, name string, opts metav1.DeleteOptions) error {
+			return clientset.BatchV1().CronJobs(ns).Delete(ctx, name, opts)
+		},
+	},
+	"ReplicationController": {
+		get: func(ctx context.Context, clientset *kubernetes.Clientset, ns, name string) (metav1.Object, error) {
+			return clientset.CoreV1().ReplicationControllers(ns).Get(ctx, name, metav1.GetOptions{})
+		},
+		delete: func(ctx context.Context, clientset *kubernetes.Clientset, ns, name string, opts metav1.DeleteOptions) error {
+			return clientset.CoreV1().ReplicationControllers(ns).Delete(ctx, name, opts)
+		},
+	},
+}
+
+var cascadePolicies = map[string]metav1.DeletionPropagation{
+	"":           metav1.DeletePropagationBackground,
+	"background": metav1.DeletePropagationBackground,
+	"foreground": metav1.DeletePropagationForeground,
+	"orphan":     metav1.DeletePropagationOrphan,
+}
+
+func (h *DeleteK8sResourceHandler) ServeHTTP(c echo.Context) error {
+	type Dependent struct {
+		Kind string `json:"kind"`
+		Name string `json:"name"`
+	}
+	type Response struct {
+		DryRun     bool        `json:"dry_run"`
+		Cascade    string      `json:"cascade"`
+		Dependents []Dependent `json:"dependents"`
+		Orphaned   bool        `json:"orphaned"`
+	}
+
+	kind, ok := deletableKinds[h.Kind]
+	if !ok {
+		return c.String(http.StatusBadRequest, "deleting "+h.Kind+" is not supported")
+	}
+	propagation, ok := cascadePolicies[h.Cascade]
+	if !ok {
+		return c.String(http.StatusBadRequest, "cascade must be foreground, background or orphan")
+	}
+	var gracePeriod *int64
+	if h.GracePeriod != "" {
+		seconds, err := strconv.ParseInt(h.GracePeriod, 10, 64)
+		if err != nil || seconds < 0 {
+			return c.String(http.StatusBadRequest, "grace period must be a non-negative number of seconds")
+		}
+		gracePeriod = &seconds
+	}
+
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "DeleteK8sResourceHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	object, err := kind.get(context.Background(), clientset, h.NS, h.Object)
+	if err != nil {
+		logger.Warnf("Failed to get %s when calling DeleteK8sResourceHandler: %v", h.Kind, err)
+		return c.String(APIStatusCode(err), err.Error())
+	}
+
+	response := Response{
+		DryRun:     h.DryRun,
+		Cascade:    string(propagation),
+		Dependents: make([]Dependent, 0),
+		Orphaned:   propagation == metav1.DeletePropagationOrphan,
+	}
+	dependents, err := OwnedDependents(clientset, h.NS, object.GetUID())
+	if err != nil {
+		logger.Warnf("Failed to get dependents when calling DeleteK8sResourceHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+	for _, dependent := range dependents {
+		response.Dependents = append(response.Dependents, Dependent{Kind: dependent.ResourceType, Name: dependent.ResourceName})
+	}
+
+	uid := object.GetUID()
+	opts := metav1.DeleteOptions{
+		GracePeriodSeconds: gracePeriod,
+		PropagationPolicy:  &propagation,
+		// Make sure a recreated object with the same name is not deleted instead
+		Preconditions: &metav1.Preconditions{UID: &uid},
+	}
+	if h.DryRun {
+		opts.DryRun = []string{metav1.DryRunAll}
+		if err := kind.delete(context.Background(), clientset, h.NS, h.Object, opts); err != nil {
+			return c.String(APIStatusCode(err), err.Error())
+		}
+		return c.JSON(http.StatusOK, response)
+	}
+
+	details := map[string]string{"cascade": string(propagation)}
+	if gracePeriod != nil {
+		details["grace_period"] = h.GracePeriod
+	}
+	audit := StartAudit(c, "delete", AuditTarget{ClusterID: h.ID, ClusterName: h.Name, Namespace: h.NS, Kind: h.Kind, Name: h.Object}, details)
+	err = kind.delete(context.Background(), clientset, h.NS, h.Object, opts)
+	audit.Finish(err)
+	if err != nil {
+		logger.Warnf("Failed to delete %s when calling DeleteK8sResourceHandler: %v", h.Kind, err)
+		return c.String(APIStatusCode(err), err.Error())
+	}
+
+	LogActivityConsoleAdd(fmt.Sprintf("%s %s/%s deleted with %s cascade", h.Kind, h.NS, h.Object, propagation), DeleteActivityType)
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// APIStatusCode is the HTTP status the API server answered with, e.g. 403, 404 or 409 from a failed precondition,
+// and 500 for errors which did not come from the API server
+func APIStatusCode(err error) int {
+	var status apierrors.APIStatus
+	if errors.As(err, &status) && status.Status().Code != 0 {
+		return int(status.Status().Code)
+	}
+	return http.StatusInternalServerError
+}
+
+// OwnedDependents walks owner references down from an object through ReplicaSets, Jobs and Pods,
+// these are the objects the garbage collector removes with it
+func OwnedDependents(clientset *kubernetes.Clientset, ns string, owner types.UID) ([]KubernetesResource, error) {
+	ctx := context.Background()
+	replicaSets, err := clientset.AppsV1().ReplicaSets(ns).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	jobs, err := clientset.BatchV1().Jobs(ns).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	pods, err := clientset.CoreV1().Pods(ns).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	objects := make([]metav1.Object, 0)
+	kinds := make([]string, 0)
+	for i := range replicaSets.Items {
+		objects, kinds = append(objects, &replicaSets.Items[i]), append(kinds, "ReplicaSet")
+	}
+	for i := range jobs.Items {
+		objects, kinds = append(objects, &jobs.Items[i]), append(kinds, "Job")
+	}
+	for i := range pods.Items {
+		objects, kinds = append(objects, &pods.Items[i]), append(kinds, "Pod")
+	}
+
+	result := make([]KubernetesResource, 0)
+	owners := []types.UID{owner}
+	seen := map[types.UID]bool{owner: true}
+	for len(owners) > 0 {
+		current := owners[0]
+		owners = owners[1:]
+		for i, object := range objects {
+			if seen[object.GetUID()] {
+				continue
+			}
+			for _, ref := range object.GetOwnerReferences() {
+				if ref.UID == current {
+					seen[object.GetUID()] = true
+					owners = append(owners, object.GetUID())
+					result = append(result, KubernetesResource{
+						ResourceName:      object.GetName(),
+						ResourceType:      kinds[i],
+						ResourceNamespace: ns,
+					})
+					break
+				}
+			}
+		}
+	}
+	return result, nil
+}
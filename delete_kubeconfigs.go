@@ -21,5 +21,6 @@ func (h *DeleteKubeconfigHandler) ServeHTTP(c echo.Context) error {
 		logger.Warnf("Failed to delete Kubeconfig when calling DeleteKubeconfigHandler: %v", err)
 		return c.NoContent(http.StatusInternalServerError)
 	}
+	K8sCache.Forget(h.ID)
 	return c.NoContent(http.StatusOK)
 }
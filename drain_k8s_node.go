@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type DrainK8sNodeHandler struct {
+	ID   string
+	Name string
+	Node string
+}
+
+// ServeHTTP cordons the node and evicts its pods in the background like kubectl drain,
+// pods blocking the drain are answered with 409 before anything is changed
+func (h *DrainK8sNodeHandler) ServeHTTP(c echo.Context) error {
+	type drainType struct {
+		IgnoreDaemonSets   bool   `json:"ignore_daemonsets"`
+		DeleteEmptyDirData bool   `json:"delete_emptydir_data"`
+		Force              bool   `json:"force"`
+		GracePeriod        *int64 `json:"grace_period"`
+		TimeoutSeconds     int64  `json:"timeout_seconds"`
+		DryRun             bool   `json:"dry_run"`
+	}
+	type Response struct {
+		Node        string     `json:"node"`
+		DryRun      bool       `json:"dry_run"`
+		OperationID string     `json:"operation_id"`
+		Message     string     `json:"message"`
+		Pods        []DrainPod `json:"pods"`
+	}
+	var drainPost drainType
+	if err := c.Bind(&drainPost); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	if drainPost.GracePeriod != nil && *drainPost.GracePeriod < 0 {
+		return c.String(http.StatusBadRequest, "grace period must be a non-negative number of seconds")
+	}
+	if drainPost.TimeoutSeconds < 0 {
+		return c.String(http.StatusBadRequest, "timeout must be a non-negative number of seconds")
+	}
+	options := DrainOptions{
+		IgnoreDaemonSets:   drainPost.IgnoreDaemonSets,
+		DeleteEmptyDirData: drainPost.DeleteEmptyDirData,
+		Force:              drainPost.Force,
+		GracePeriod:        drainPost.GracePeriod,
+		Timeout:            time.Duration(drainPost.TimeoutSeconds) * time.Second,
+		DryRun:             drainPost.DryRun,
+	}
+
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "DrainK8sNodeHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	if _, err := clientset.CoreV1().Nodes().Get(context.Background(), h.Node, metav1.GetOptions{}); err != nil {
+		if apierrors.IsNotFound(err) {
+			return c.String(http.StatusNotFound, err.Error())
+		}
+		logger.Warnf("Failed to get node when calling DrainK8sNodeHandler: %v", err)
+		return c.String(http.StatusInternalServerError, err.Error())
+	}
+	pods, drainPods, err := NodeDrainPods(context.Background(), clientset, h.Node, options)
+	if err != nil {
+		logger.Warnf("Failed to list pods when calling DrainK8sNodeHandler: %v", err)
+		return c.String(http.StatusInternalServerError, err.Error())
+	}
+	response := Response{
+		Node:   h.Node,
+		DryRun: drainPost.DryRun,
+		Pods:   drainPods,
+	}
+	blocking := 0
+	for _, pod := range drainPods {
+		if pod.Blocking {
+			blocking++
+		}
+	}
+	if blocking > 0 {
+		response.Message = fmt.Sprintf("%d pods block the drain", blocking)
+		return c.JSON(http.StatusConflict, response)
+	}
+	if drainPost.DryRun {
+		response.Message = fmt.Sprintf("%d pods would be evicted", len(pods))
+		return c.JSON(http.StatusOK, response)
+	}
+
+	audit := StartAudit(c, "drain", AuditTarget{ClusterID: h.ID, ClusterName: h.Name, Kind: "Node", Name: h.Node}, map[string]string{
+		"ignore_daemonsets":    strconv.FormatBool(options.IgnoreDaemonSets),
+		"delete_emptydir_data": strconv.FormatBool(options.DeleteEmptyDirData),
+		"force":                strconv.FormatBool(options.Force),
+		"pods":                 strconv.Itoa(len(pods)),
+	})
+	if _, err := CordonNode(context.Background(), clientset, h.Node, true); err != nil {
+		audit.Finish(err)
+		logger.Warnf("Failed to cordon node when calling DrainK8sNodeHandler: %v", err)
+		return c.String(http.StatusInternalServerError, err.Error())
+	}
+
+	operation := NewActivityOperation(NodeActivityType, fmt.Sprintf("Drain of Node %s", h.Node), audit.Target)
+	operation.Log(ActivityRequested, fmt.Sprintf("node cordoned, evicting %d pods", len(pods)))
+	go func() {
+		err := DrainNode(clientset, pods, options, operation)
+		audit.Finish(err)
+		if err != nil {
+			logger.Warnf("Drain of node %s failed: %v", h.Node, err)
+			operation.Log(ActivityFailed, err.Error())
+			return
+		}
+		operation.Log(ActivityCompleted, "node drained")
+	}()
+
+	response.OperationID = operation.ID
+	response.Message = fmt.Sprintf("draining %d pods", len(pods))
+	return c.JSON(http.StatusAccepted, response)
+}
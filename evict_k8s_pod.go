@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
+)
+
+type EvictK8sPodHandler struct {
+	ID   string
+	Name string
+	NS   string
+	Pod  string
+}
+
+// ServeHTTP evicts a pod through the Eviction API, refusals by a PodDisruptionBudget are answered with 429
+// and the budgets that refused
+func (h *EvictK8sPodHandler) ServeHTTP(c echo.Context) error {
+	type evictType struct {
+		GracePeriod *int64 `json:"grace_period"`
+		DryRun      bool   `json:"dry_run"`
+	}
+	type Response struct {
+		DryRun  bool        `json:"dry_run"`
+		Evicted bool        `json:"evicted"`
+		Message string      `json:"message"`
+		PDBs    []PDBStatus `json:"pdbs"`
+	}
+	var evictPost evictType
+	if err := c.Bind(&evictPost); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	if evictPost.GracePeriod != nil && *evictPost.GracePeriod < 0 {
+		return c.String(http.StatusBadRequest, "grace period must be a non-negative number of seconds")
+	}
+
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "EvictK8sPodHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	pod, err := clientset.CoreV1().Pods(h.NS).Get(context.Background(), h.Pod, metav1.GetOptions{})
+	if err != nil {
+		logger.Warnf("Failed to get pod when calling EvictK8sPodHandler: %v", err)
+		return c.String(http.StatusInternalServerError, err.Error())
+	}
+
+	var audit *AuditEntry
+	if !evictPost.DryRun {
+		audit = StartAudit(c, "evict", AuditTarget{ClusterID: h.ID, ClusterName: h.Name, Namespace: h.NS, Kind: "Pod", Name: h.Pod}, nil)
+	}
+	err = EvictPod(context.Background(), clientset, *pod, evictPost.GracePeriod, evictPost.DryRun)
+	if audit != nil {
+		audit.Finish(err)
+	}
+
+	response := Response{
+		DryRun:  evictPost.DryRun,
+		Evicted: err == nil,
+		PDBs:    make([]PDBStatus, 0),
+	}
+	var evictionErr *EvictionError
+	if errors.As(err, &evictionErr) {
+		response.Message = evictionErr.Error()
+		response.PDBs = evictionErr.PDBs
+		return c.JSON(http.StatusTooManyRequests, response)
+	}
+	if err != nil {
+		logger.Warnf("Failed to evict pod when calling EvictK8sPodHandler: %v", err)
+		return c.String(http.StatusInternalServerError, err.Error())
+	}
+
+	response.Message = "pod evicted"
+	if evictPost.DryRun {
+		response.Message = "pod can be evicted"
+	} else {
+		LogActivityConsoleAdd(fmt.Sprintf("Pod %s/%s evicted", h.NS, h.Pod), DeleteActivityType)
+	}
+	return c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/gorilla/websocket"
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	"io"
+	v1core "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// wsUpgrader accepts connections from the frontend and from the same host
+var wsUpgrader = websocket.Upgrader{
+	ReadBufferSize:  4096,
+	WriteBufferSize: 4096,
+	CheckOrigin: func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		return origin == "" || origin == FrontendOrigin || strings.TrimPrefix(strings.TrimPrefix(origin, "https://"), "http://") == r.Host
+	},
+}
+
+type ExecK8sPodHandler struct {
+	ID        string
+	Name      string
+	NS        string
+	Pod       string
+	Container string
+	Command   []string
+	TTY       bool
+	Record    bool
+}
+
+// ExecMessage is sent by the client: stdin carries typed data, resize the new terminal size
+type ExecMessage struct {
+	Type string `json:"type"`
+	Data string `json:"data"`
+	Cols uint16 `json:"cols"`
+	Rows uint16 `json:"rows"`
+}
+
+// ExecExit is sent to the client as the last message of a session
+type ExecExit struct {
+	Type    string `json:"type"`
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (h *ExecK8sPodHandler) ServeHTTP(c echo.Context) error {
+	restConfig, errMsg, err := GetRestConfig(h.ID, h.Name, "ExecK8sPodHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "ExecK8sPodHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	container := h.Container
+	if container == "" {
+		pod, err := clientset.CoreV1().Pods(h.NS).Get(context.Background(), h.Pod, v1.GetOptions{})
+		if err != nil {
+			logger.Warnf("Failed to get pod when calling ExecK8sPodHandler: %v", err)
+			return c.NoContent(http.StatusInternalServerError)
+		}
+		container = DefaultContainer(*pod)
+	}
+	command := h.Command
+	if len(command) == 0 {
+		command = []string{"/bin/sh"}
+	}
+
+	req := clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(h.Pod).
+		Namespace(h.NS).
+		SubResource("exec").
+		VersionedParams(&v1core.PodExecOptions{
+			Container: container,
+			Command:   command,
+			Stdin:     true,
+			Stdout:    true,
+			Stderr:    !h.TTY,
+			TTY:       h.TTY,
+		}, scheme.ParameterCodec)
+
+	// Prefer the WebSocket protocol and fall back to SPDY for API servers that do not support it
+	websocketExecutor, err := remotecommand.NewWebSocketExecutor(restConfig, "GET", req.URL().String())
+	if err != nil {
+		logger.Warnf("Failed to create websocket executor when calling ExecK8sPodHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+	spdyExecutor, err := remotecommand.NewSPDYExecutor(restConfig, "POST", req.URL())
+	if err != nil {
+		logger.Warnf("Failed to create spdy executor when calling ExecK8sPodHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+	executor, err := remotecommand.NewFallbackExecutor(websocketExecutor, spdyExecutor, httpstream.IsUpgradeFailure)
+	if err != nil {
+		logger.Warnf("Failed to create executor when calling ExecK8sPodHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	conn, err := wsUpgrader.Upgrade(c.Response(), c.Request(), nil)
+	if err != nil {
+		logger.Warnf("Failed to upgrade connection when calling ExecK8sPodHandler: %v", err)
+		return nil
+	}
+	defer conn.Close()
+
+	audit := StartAudit(c, "exec", AuditTarget{ClusterID: h.ID, ClusterName: h.Name, Namespace: h.NS, Kind: "Pod", Name: h.Pod}, map[string]string{
+		"container": container,
+		"command":   strings.Join(command, " "),
+		"recorded":  strconv.FormatBool(h.Record),
+	})
+	var transcript *ExecTranscript
+	if h.Record {
+		transcript = NewExecTranscript(audit.ID)
+	}
+
+	session := &execSession{conn: conn, transcript: transcript, resize: make(chan remotecommand.TerminalSize, 1)}
+	stdinReader, stdinWriter := io.Pipe()
+
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+	go func() {
+		// The session ends when the client closes the connection
+		defer cancel()
+		defer stdinWriter.Close()
+		session.readClient(stdinWriter)
+	}()
+
+	streamOptions := remotecommand.StreamOptions{
+		Stdin:  stdinReader,
+		Stdout: session,
+		Tty:    h.TTY,
+	}
+	if h.TTY {
+		streamOptions.TerminalSizeQueue = session
+	} else {
+		streamOptions.Stderr = session
+	}
+	err = executor.StreamWithContext(ctx, streamOptions)
+	_ = stdinReader.Close()
+
+	exit := ExecExit{Type: "exit"}
+	var exitErr utilexec.ExitError
+	if err != nil {
+		if errors.As(err, &exitErr) {
+			exit.Code = exitErr.ExitStatus()
+		} else {
+			exit.Code = -1
+		}
+		exit.Message = err.Error()
+		if ctx.Err() == nil && exit.Code == -1 {
+			logger.Warnf("Exec into %s/%s failed when calling ExecK8sPodHandler: %v", h.NS, h.Pod, err)
+		}
+	}
+	if transcript != nil {
+		transcript.Close()
+	}
+	audit.Finish(err)
+
+	session.writeJSON(exit)
+	_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	return nil
+}
+
+// execSession connects the exec streams to the WebSocket of the client
+type execSession struct {
+	conn       *websocket.Conn
+	writeMu    sync.Mutex
+	transcript *ExecTranscript
+	resize     chan remotecommand.TerminalSize
+}
+
+// Write sends command output to the client as binary messages
+func (s *execSession) Write(p []byte) (int, error) {
+	if s.transcript != nil {
+		s.transcript.Output(p)
+	}
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	if err := s.conn.WriteMessage(websocket.BinaryMessage, p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (s *execSession) writeJSON(v interface{}) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	_ = s.conn.WriteJSON(v)
+}
+
+// Next implements remotecommand.TerminalSizeQueue, nil ends the queue
+func (s *execSession) Next() *remotecommand.TerminalSize {
+	size, ok := <-s.resize
+	if !ok {
+		return nil
+	}
+	return &size
+}
+
+func (s *execSession) readClient(stdin io.Writer) {
+	defer close(s.resize)
+	for {
+		_, data, err := s.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		var message ExecMessage
+		if err := json.Unmarshal(data, &message); err != nil {
+			continue
+		}
+		switch message.Type {
+		case "stdin":
+			if s.transcript != nil {
+				s.transcript.Input([]byte(message.Data))
+			}
+			if _, err := stdin.Write([]byte(message.Data)); err != nil {
+				return
+			}
+		case "resize":
+			if message.Cols == 0 || message.Rows == 0 {
+				continue
+			}
+			// Only the latest size matters, drop a pending one
+			select {
+			case <-s.resize:
+			default:
+			}
+			s.resize <- remotecommand.TerminalSize{Width: message.Cols, Height: message.Rows}
+		}
+	}
+}
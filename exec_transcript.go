@@ -0,0 +1,78 @@
+package main
+
+import (
+	logger "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"sync"
+	"time"
+)
+
+// execTranscriptChunkSize is the amount of buffered terminal data written to the database at once
+const execTranscriptChunkSize = 32 * 1024
+
+type ExecTranscriptChunk struct {
+	ID      primitive.ObjectID `bson:"_id" json:"id"`
+	AuditID primitive.ObjectID `bson:"audit_id" json:"audit_id"`
+	Seq     int                `bson:"seq" json:"seq"`
+	Time    time.Time          `bson:"time" json:"time"`
+	Input   string             `bson:"input" json:"input"`
+	Output  string             `bson:"output" json:"output"`
+}
+
+// ExecTranscript buffers what was typed into and printed by an exec session and stores it in chunks
+// linked to the audit entry of the session
+type ExecTranscript struct {
+	mu      sync.Mutex
+	auditID primitive.ObjectID
+	seq     int
+	input   []byte
+	output  []byte
+}
+
+func NewExecTranscript(auditID primitive.ObjectID) *ExecTranscript {
+	return &ExecTranscript{auditID: auditID}
+}
+
+func (t *ExecTranscript) Input(data []byte) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.input = append(t.input, data...)
+	if len(t.input)+len(t.output) >= execTranscriptChunkSize {
+		t.flush()
+	}
+}
+
+func (t *ExecTranscript) Output(data []byte) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.output = append(t.output, data...)
+	if len(t.input)+len(t.output) >= execTranscriptChunkSize {
+		t.flush()
+	}
+}
+
+func (t *ExecTranscript) Close() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.flush()
+}
+
+func (t *ExecTranscript) flush() {
+	if len(t.input) == 0 && len(t.output) == 0 {
+		return
+	}
+	chunk := ExecTranscriptChunk{
+		ID:      primitive.NewObjectID(),
+		AuditID: t.auditID,
+		Seq:     t.seq,
+		Time:    time.Now(),
+		Input:   string(t.input),
+		Output:  string(t.output),
+	}
+	if err := DBHelper.InsertOne(ExecTranscripts, chunk); err != nil {
+		logger.Warnf("Failed to write exec transcript of session %s: %v", t.auditID.Hex(), err)
+	}
+	t.seq++
+	t.input = t.input[:0]
+	t.output = t.output[:0]
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	v1 "k8s.io/api/apps/v1"
 	v1core "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes"
@@ -85,6 +86,19 @@ func (s ServiceAccountStatus) OK() bool {
 	return s.Ok
 }
 
+type RoleBindingStatus struct {
+	Ok        bool     `json:"ok"`
+	Namespace string   `json:"namespace"`
+	Created   string   `json:"created"`
+	Role      string   `json:"role"`
+	Subjects  []string `json:"subjects"`
+	Message   string   `json:"message"`
+}
+
+func (s RoleBindingStatus) OK() bool {
+	return s.Ok
+}
+
 type IngressStatus struct {
 	Ok             bool   `json:"ok"`
 	Namespace      string `json:"namespace"`
@@ -235,6 +249,11 @@ func (dt *DAGTraverser) GenerateDAGForResource(clientSet *kubernetes.Clientset,
 		if err != nil {
 			return fmt.Errorf("unable to get related resources for ServiceAccount: %v", err)
 		}
+	case "RoleBinding", "ClusterRoleBinding":
+		relatedResources, status, err = dt.getRelatedResourcesRoleBinding(clientSet, kr)
+		if err != nil {
+			return fmt.Errorf("unable to get related resources for %s: %v", kr.ResourceType, err)
+		}
 	case "Ingress":
 		relatedResources, status, err = dt.getRelatedResourcesIngress(clientSet, kr)
 		if err != nil {
@@ -696,8 +715,11 @@ func (dt *DAGTraverser) getRelatedResourcesService(clientSet *kubernetes.Clients
 		}
 		for _, ingress := range ingresses.Items {
 			for _, rule := range ingress.Spec.Rules {
+				if rule.IngressRuleValue.HTTP == nil {
+					continue
+				}
 				for _, path := range rule.IngressRuleValue.HTTP.Paths {
-					if path.Backend.Service.Name == kr.ResourceName {
+					if path.Backend.Service != nil && path.Backend.Service.Name == kr.ResourceName {
 						relatedResources = append(relatedResources, KubernetesResource{
 							ResourceName:      ingress.Name,
 							ResourceType:      "Ingress",
@@ -786,6 +808,84 @@ func (dt *DAGTraverser) getRelatedResourcesServiceAccount(clientSet *kubernetes.
 		})
 	}
 
+	// Bindings that name the service account directly, bindings to its groups would link it to most of the cluster
+	subject := RBACSubject{
+		Kind:      rbacv1.ServiceAccountKind,
+		Name:      serviceAccount.Name,
+		Namespace: serviceAccount.Namespace,
+	}
+	roleBindings, err := clientSet.RbacV1().RoleBindings(serviceAccount.Namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, ServiceAccountStatus{}, fmt.Errorf("unable to list role bindings: %v", err)
+	}
+	for _, binding := range roleBindings.Items {
+		for _, s := range binding.Subjects {
+			if NewRBACSubject(s, binding.Namespace) == subject {
+				relatedResources = append(relatedResources, KubernetesResource{
+					ResourceName:      binding.Name,
+					ResourceType:      "RoleBinding",
+					ResourceNamespace: binding.Namespace,
+				})
+				break
+			}
+		}
+	}
+	clusterRoleBindings, err := clientSet.RbacV1().ClusterRoleBindings().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, ServiceAccountStatus{}, fmt.Errorf("unable to list cluster role bindings: %v", err)
+	}
+	for _, binding := range clusterRoleBindings.Items {
+		for _, s := range binding.Subjects {
+			if NewRBACSubject(s, "") == subject {
+				relatedResources = append(relatedResources, KubernetesResource{
+					ResourceName: binding.Name,
+					ResourceType: "ClusterRoleBinding",
+				})
+				break
+			}
+		}
+	}
+
+	return
+}
+
+func (dt *DAGTraverser) getRelatedResourcesRoleBinding(clientSet *kubernetes.Clientset, kr KubernetesResource) (relatedResources []KubernetesResource, status RoleBindingStatus, err error) {
+	var roleRef rbacv1.RoleRef
+	var subjects []rbacv1.Subject
+	var created metav1.Time
+	if kr.ResourceType == "ClusterRoleBinding" {
+		binding, err := clientSet.RbacV1().ClusterRoleBindings().Get(context.TODO(), kr.ResourceName, metav1.GetOptions{})
+		if err != nil {
+			return nil, RoleBindingStatus{}, fmt.Errorf("unable to get cluster role binding: %v", err)
+		}
+		roleRef, subjects, created = binding.RoleRef, binding.Subjects, binding.CreationTimestamp
+	} else {
+		binding, err := clientSet.RbacV1().RoleBindings(kr.ResourceNamespace).Get(context.TODO(), kr.ResourceName, metav1.GetOptions{})
+		if err != nil {
+			return nil, RoleBindingStatus{}, fmt.Errorf("unable to get role binding: %v", err)
+		}
+		roleRef, subjects, created = binding.RoleRef, binding.Subjects, binding.CreationTimestamp
+	}
+
+	status = RoleBindingStatus{
+		Ok:        true,
+		Namespace: kr.ResourceNamespace,
+		Created:   ElapsedTimeShort(created.Time),
+		Role:      roleRef.Kind + "/" + roleRef.Name,
+		Subjects:  FormatSubjects(subjects, kr.ResourceNamespace),
+		Message:   kr.ResourceType + " is OK",
+	}
+
+	if roleRef.Kind == "ClusterRole" {
+		_, err = clientSet.RbacV1().ClusterRoles().Get(context.TODO(), roleRef.Name, metav1.GetOptions{})
+	} else {
+		_, err = clientSet.RbacV1().Roles(kr.ResourceNamespace).Get(context.TODO(), roleRef.Name, metav1.GetOptions{})
+	}
+	if err != nil {
+		status.Ok = false
+		status.Message = err.Error()
+		err = nil
+	}
 	return
 }
 
@@ -801,7 +901,7 @@ func (dt *DAGTraverser) getRelatedResourcesIngress(clientSet *kubernetes.Clients
 		Created:   ElapsedTimeShort(ingress.CreationTimestamp.Time),
 		Message:   "Ingress is OK",
 	}
-	if ingress.Spec.DefaultBackend != nil {
+	if ingress.Spec.DefaultBackend != nil && ingress.Spec.DefaultBackend.Service != nil {
 		status.DefaultBackend = ingress.Spec.DefaultBackend.Service.Name
 	}
 
@@ -815,7 +915,13 @@ func (dt *DAGTraverser) getRelatedResourcesIngress(clientSet *kubernetes.Clients
 
 	if !serviceVisited {
 		for _, rule := range ingress.Spec.Rules {
+			if rule.HTTP == nil {
+				continue
+			}
 			for _, path := range rule.HTTP.Paths {
+				if path.Backend.Service == nil {
+					continue
+				}
 				relatedResources = append(relatedResources, KubernetesResource{
 					ResourceName:      path.Backend.Service.Name,
 					ResourceType:      "Service",
@@ -3,10 +3,26 @@ package main
 import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 )
 
 func GetClientSet(id, name, handler string) (*kubernetes.Clientset, string, error) {
+	kubeConfig, errMsg, err := GetRestConfig(id, name, handler)
+	if err != nil {
+		return nil, errMsg, err
+	}
+
+	clientset, err := kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		return nil, "Failed to create clientset when calling " + handler, err
+	}
+
+	return clientset, "", nil
+}
+
+// GetRestConfig returns the client config of a cluster, for clients that need more than the clientset, e.g. exec streams
+func GetRestConfig(id, name, handler string) (*rest.Config, string, error) {
 	var k8sConfig Kubeconfig
 	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
@@ -31,10 +47,5 @@ func GetClientSet(id, name, handler string) (*kubernetes.Clientset, string, erro
 		return nil, "Failed to parse kubeconfig when calling " + handler, err
 	}
 
-	clientset, err := kubernetes.NewForConfig(kubeConfig)
-	if err != nil {
-		return nil, "Failed to create clientset when calling " + handler, err
-	}
-
-	return clientset, "", nil
+	return kubeConfig, "", nil
 }
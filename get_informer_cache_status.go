@@ -0,0 +1,13 @@
+package main
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type GetInformerCacheStatusHandler struct {
+}
+
+func (h *GetInformerCacheStatusHandler) ServeHTTP(c echo.Context) error {
+	return c.JSON(http.StatusOK, K8sCache.Status())
+}
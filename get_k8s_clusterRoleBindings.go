@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
+)
+
+type GetK8sClusterRoleBindingsHandler struct {
+	ID   string
+	Name string
+}
+
+type ClusterRoleBindingRow struct {
+	ID        string       `json:"id"`
+	Name      string       `json:"name"`
+	Role      string       `json:"role"`
+	Subjects  []string     `json:"subjects"`
+	Age       string       `json:"age"`
+	Labels    []string     `json:"labels"`
+	Condition RowCondition `json:"condition"`
+}
+
+func (h *GetK8sClusterRoleBindingsHandler) ServeHTTP(c echo.Context) error {
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "GetK8sClusterRoleBindingsHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	clusterRoleBindings, err := clientset.RbacV1().ClusterRoleBindings().List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		logger.Warnf("Failed to get cluster role bindings when calling GetK8sClusterRoleBindingsHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	clusterRoles, err := clientset.RbacV1().ClusterRoles().List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		logger.Warnf("Failed to get cluster roles when calling GetK8sClusterRoleBindingsHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+	existing := make(map[string]bool)
+	for _, clusterRole := range clusterRoles.Items {
+		existing[clusterRole.Name] = true
+	}
+
+	var response = make([]ClusterRoleBindingRow, 0)
+	for _, clusterRoleBinding := range clusterRoleBindings.Items {
+		response = append(response, NewClusterRoleBindingRow(clusterRoleBinding, existing[clusterRoleBinding.RoleRef.Name]))
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func NewClusterRoleBindingRow(clusterRoleBinding v1.ClusterRoleBinding, roleFound bool) ClusterRoleBindingRow {
+	name := clusterRoleBinding.GenerateName + clusterRoleBinding.Name
+
+	age := clusterRoleBinding.GetObjectMeta().GetCreationTimestamp()
+
+	row := ClusterRoleBindingRow{
+		ID:       GenerateRandomString(10),
+		Name:     name,
+		Role:     clusterRoleBinding.RoleRef.Kind + "/" + clusterRoleBinding.RoleRef.Name,
+		Subjects: FormatSubjects(clusterRoleBinding.Subjects, ""),
+		Age:      ElapsedTimeShort(age.Time),
+		Labels:   FormatKeyValues(clusterRoleBinding.GetLabels()),
+		Condition: RowCondition{
+			OK:      true,
+			Message: "Cluster Role Binding is OK",
+		},
+	}
+	if !roleFound {
+		row.Condition = RowCondition{
+			OK:      false,
+			Message: row.Role + " does not exist",
+		}
+	}
+
+	return row
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
+)
+
+type GetK8sClusterRolesHandler struct {
+	ID   string
+	Name string
+}
+
+type ClusterRoleRow struct {
+	ID         string       `json:"id"`
+	Name       string       `json:"name"`
+	Rules      []string     `json:"rules"`
+	Aggregated bool         `json:"aggregated"`
+	Age        string       `json:"age"`
+	Labels     []string     `json:"labels"`
+	Condition  RowCondition `json:"condition"`
+}
+
+func (h *GetK8sClusterRolesHandler) ServeHTTP(c echo.Context) error {
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "GetK8sClusterRolesHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	clusterRoles, err := clientset.RbacV1().ClusterRoles().List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		logger.Warnf("Failed to get cluster roles when calling GetK8sClusterRolesHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	var response = make([]ClusterRoleRow, 0)
+	for _, clusterRole := range clusterRoles.Items {
+		response = append(response, NewClusterRoleRow(clusterRole))
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func NewClusterRoleRow(clusterRole v1.ClusterRole) ClusterRoleRow {
+	name := clusterRole.GenerateName + clusterRole.Name
+
+	age := clusterRole.GetObjectMeta().GetCreationTimestamp()
+
+	return ClusterRoleRow{
+		ID:         GenerateRandomString(10),
+		Name:       name,
+		Rules:      FormatPolicyRules(clusterRole.Rules),
+		Aggregated: clusterRole.AggregationRule != nil,
+		Age:        ElapsedTimeShort(age.Time),
+		Labels:     FormatKeyValues(clusterRole.GetLabels()),
+		Condition: RowCondition{
+			OK:      true,
+			Message: "Cluster Role is OK",
+		},
+	}
+}
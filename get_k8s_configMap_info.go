@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
+)
+
+type GetK8sConfigMapInfoHandler struct {
+	ID        string
+	Name      string
+	NS        string
+	ConfigMap string
+}
+
+func (h *GetK8sConfigMapInfoHandler) ServeHTTP(c echo.Context) error {
+	type Summary struct {
+		Keys      []ConfigMapKey `json:"keys"`
+		Size      int            `json:"size"`
+		Immutable bool           `json:"immutable"`
+		UsedBy    []string       `json:"used_by"`
+	}
+	type Response struct {
+		Summary  Summary          `json:"summary"`
+		Metadata ResourceMetadata `json:"metadata"`
+		YAML     string           `json:"yaml"`
+	}
+
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "GetK8sConfigMapInfoHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	configMap, err := clientset.CoreV1().ConfigMaps(h.NS).Get(context.Background(), h.ConfigMap, v1.GetOptions{})
+	if err != nil {
+		logger.Warnf("Failed to get config map when calling GetK8sConfigMapInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	pods, err := ListPods(clientset, h.ID, h.Name, h.NS)
+	if err != nil {
+		logger.Warnf("Failed to get pods when calling GetK8sConfigMapInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	row := NewConfigMapRow(*configMap)
+	summary := Summary{
+		Keys:      row.Keys,
+		Size:      row.Size,
+		Immutable: row.Immutable,
+		UsedBy:    PodsUsing(pods, "ConfigMap", configMap.Name),
+	}
+
+	yamlStr, err := ResourceYAML(configMap, "v1", "ConfigMap")
+	if err != nil {
+		logger.Warnf("Error marshaling to YAML when calling GetK8sConfigMapInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	response := Response{
+		Summary:  summary,
+		Metadata: NewResourceMetadata(configMap),
+		YAML:     yamlStr,
+	}
+	return c.JSON(http.StatusOK, response)
+}
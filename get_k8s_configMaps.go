@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
+	"sort"
+)
+
+type GetK8sConfigMapsHandler struct {
+	ID   string
+	Name string
+	NS   string
+}
+
+type ConfigMapKey struct {
+	Key    string `json:"key"`
+	Size   int    `json:"size"`
+	Binary bool   `json:"binary"`
+}
+
+type ConfigMapRow struct {
+	ID        string         `json:"id"`
+	Name      string         `json:"name"`
+	Keys      []ConfigMapKey `json:"keys"`
+	Size      int            `json:"size"`
+	Immutable bool           `json:"immutable"`
+	Age       string         `json:"age"`
+	Labels    []string       `json:"labels"`
+	Condition RowCondition   `json:"condition"`
+}
+
+func (h *GetK8sConfigMapsHandler) ServeHTTP(c echo.Context) error {
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "GetK8sConfigMapsHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	configMaps, err := clientset.CoreV1().ConfigMaps(h.NS).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		logger.Warnf("Failed to get config maps when calling GetK8sConfigMapsHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	var response = make([]ConfigMapRow, 0)
+	for _, configMap := range configMaps.Items {
+		response = append(response, NewConfigMapRow(configMap))
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func NewConfigMapRow(configMap v1.ConfigMap) ConfigMapRow {
+	name := configMap.GenerateName + configMap.Name
+
+	age := configMap.GetObjectMeta().GetCreationTimestamp()
+
+	keys := make([]ConfigMapKey, 0)
+	size := 0
+	for key, value := range configMap.Data {
+		keys = append(keys, ConfigMapKey{Key: key, Size: len(value)})
+		size += len(value)
+	}
+	for key, value := range configMap.BinaryData {
+		keys = append(keys, ConfigMapKey{Key: key, Size: len(value), Binary: true})
+		size += len(value)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].Key < keys[j].Key
+	})
+
+	return ConfigMapRow{
+		ID:        GenerateRandomString(10),
+		Name:      name,
+		Keys:      keys,
+		Size:      size,
+		Immutable: configMap.Immutable != nil && *configMap.Immutable,
+		Age:       ElapsedTimeShort(age.Time),
+		Labels:    FormatKeyValues(configMap.GetLabels()),
+		Condition: RowCondition{
+			OK:      true,
+			Message: "Config Map is OK",
+		},
+	}
+}
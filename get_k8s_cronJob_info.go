@@ -0,0 +1,202 @@
+package main
+
+import (
+	"context"
+	"github.com/labstack/echo/v4"
+	"github.com/robfig/cron/v3"
+	logger "github.com/sirupsen/logrus"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
+	"sort"
+	"time"
+)
+
+type GetK8sCronJobInfoHandler struct {
+	ID      string
+	Name    string
+	NS      string
+	CronJob string
+}
+
+func (h *GetK8sCronJobInfoHandler) ServeHTTP(c echo.Context) error {
+	type Configuration struct {
+		Schedule                   string `json:"schedule"`
+		TimeZone                   string `json:"time_zone"`
+		ConcurrencyPolicy          string `json:"concurrency_policy"`
+		Suspend                    bool   `json:"suspend"`
+		StartingDeadlineSeconds    int64  `json:"starting_deadline_seconds"`
+		SuccessfulJobsHistoryLimit int32  `json:"successful_jobs_history_limit"`
+		FailedJobsHistoryLimit     int32  `json:"failed_jobs_history_limit"`
+	}
+	type Status struct {
+		LastSchedule   string `json:"last_schedule"`
+		LastSuccessful string `json:"last_successful"`
+		NextSchedule   string `json:"next_schedule"`
+	}
+	type HistoryJob struct {
+		Name           string `json:"name"`
+		Status         string `json:"status"`
+		StartTime      string `json:"start_time"`
+		CompletionTime string `json:"completion_time"`
+		Duration       string `json:"duration"`
+		Age            string `json:"age"`
+	}
+	type Summary struct {
+		Configuration Configuration      `json:"configuration"`
+		Status        Status             `json:"status"`
+		ActiveJobs    []string           `json:"active_jobs"`
+		History       []HistoryJob       `json:"history"`
+		Template      []SummaryContainer `json:"template"`
+		Volumes       []SummaryVolume    `json:"volumes"`
+		Events        []ObjectEvent      `json:"events"`
+	}
+	type Response struct {
+		Summary  Summary          `json:"summary"`
+		Metadata ResourceMetadata `json:"metadata"`
+		YAML     string           `json:"yaml"`
+	}
+
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "GetK8sCronJobInfoHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	cronJob, err := clientset.BatchV1().CronJobs(h.NS).Get(context.Background(), h.CronJob, v1.GetOptions{})
+	if err != nil {
+		logger.Warnf("Failed to get cron job when calling GetK8sCronJobInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	yamlStr, err := ResourceYAML(cronJob, "batch/v1", "CronJob")
+	if err != nil {
+		logger.Warnf("Error marshaling to YAML when calling GetK8sCronJobInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	configuration := Configuration{
+		Schedule:          cronJob.Spec.Schedule,
+		TimeZone:          "Local",
+		ConcurrencyPolicy: string(cronJob.Spec.ConcurrencyPolicy),
+		// Defaults applied by the API server when the fields are omitted
+		SuccessfulJobsHistoryLimit: 3,
+		FailedJobsHistoryLimit:     1,
+	}
+	if cronJob.Spec.TimeZone != nil {
+		configuration.TimeZone = *cronJob.Spec.TimeZone
+	}
+	if cronJob.Spec.Suspend != nil {
+		configuration.Suspend = *cronJob.Spec.Suspend
+	}
+	if cronJob.Spec.StartingDeadlineSeconds != nil {
+		configuration.StartingDeadlineSeconds = *cronJob.Spec.StartingDeadlineSeconds
+	}
+	if cronJob.Spec.SuccessfulJobsHistoryLimit != nil {
+		configuration.SuccessfulJobsHistoryLimit = *cronJob.Spec.SuccessfulJobsHistoryLimit
+	}
+	if cronJob.Spec.FailedJobsHistoryLimit != nil {
+		configuration.FailedJobsHistoryLimit = *cronJob.Spec.FailedJobsHistoryLimit
+	}
+
+	status := Status{}
+	if cronJob.Status.LastScheduleTime != nil {
+		status.LastSchedule = cronJob.Status.LastScheduleTime.Format(time.RFC3339)
+	}
+	if cronJob.Status.LastSuccessfulTime != nil {
+		status.LastSuccessful = cronJob.Status.LastSuccessfulTime.Format(time.RFC3339)
+	}
+	if !configuration.Suspend {
+		next, err := NextCronJobSchedule(*cronJob, time.Now())
+		if err != nil {
+			logger.Warnf("Failed to parse schedule of cron job %s when calling GetK8sCronJobInfoHandler: %v", cronJob.Name, err)
+		} else {
+			status.NextSchedule = next.Format(time.RFC3339)
+		}
+	}
+
+	summary := Summary{
+		Configuration: configuration,
+		Status:        status,
+		ActiveJobs:    make([]string, 0),
+		History:       make([]HistoryJob, 0),
+		Template:      NewSummaryContainers(cronJob.Spec.JobTemplate.Spec.Template.Spec.Containers),
+		Volumes:       NewSummaryVolumes(cronJob.Spec.JobTemplate.Spec.Template.Spec.Volumes),
+	}
+	for _, active := range cronJob.Status.Active {
+		summary.ActiveJobs = append(summary.ActiveJobs, active.Name)
+	}
+
+	jobs, err := clientset.BatchV1().Jobs(h.NS).List(context.Background(), v1.ListOptions{})
+	if err != nil {
+		logger.Warnf("Failed to get jobs when calling GetK8sCronJobInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+	owned := make([]batchv1.Job, 0)
+	for i := range jobs.Items {
+		if v1.IsControlledBy(&jobs.Items[i], cronJob) {
+			owned = append(owned, jobs.Items[i])
+		}
+	}
+	sort.SliceStable(owned, func(i, j int) bool {
+		return owned[j].CreationTimestamp.Before(&owned[i].CreationTimestamp)
+	})
+	for _, job := range owned {
+		historyJob := HistoryJob{
+			Name:     job.Name,
+			Status:   JobStatus(job),
+			Duration: JobDuration(job),
+			Age:      ElapsedTimeShort(job.CreationTimestamp.Time),
+		}
+		if job.Status.StartTime != nil {
+			historyJob.StartTime = job.Status.StartTime.Format(time.RFC3339)
+		}
+		if job.Status.CompletionTime != nil {
+			historyJob.CompletionTime = job.Status.CompletionTime.Format(time.RFC3339)
+		}
+		summary.History = append(summary.History, historyJob)
+	}
+
+	summary.Events, err = CollectObjectEvents(clientset, h.NS, "CronJob", h.CronJob)
+	if err != nil {
+		logger.Warnf("Failed to get events when calling GetK8sCronJobInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	response := Response{
+		Summary:  summary,
+		Metadata: NewResourceMetadata(cronJob),
+		YAML:     yamlStr,
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+// NextCronJobSchedule returns the next time the cron job is due after the given time, in the job's time zone
+func NextCronJobSchedule(cronJob batchv1.CronJob, after time.Time) (time.Time, error) {
+	schedule, err := cron.ParseStandard(cronJob.Spec.Schedule)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if cronJob.Spec.TimeZone != nil {
+		location, err := time.LoadLocation(*cronJob.Spec.TimeZone)
+		if err != nil {
+			return time.Time{}, err
+		}
+		after = after.In(location)
+	}
+	return schedule.Next(after), nil
+}
+
+// JobStatus is Complete, Failed, Suspended or Running depending on the job's terminal condition
+func JobStatus(job batchv1.Job) string {
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != "True" {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete, batchv1.JobFailed, batchv1.JobSuspended:
+			return string(condition.Type)
+		}
+	}
+	return "Running"
+}
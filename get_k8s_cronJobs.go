@@ -1,10 +1,9 @@
 package main
 
 import (
-	"context"
 	"github.com/labstack/echo/v4"
 	logger "github.com/sirupsen/logrus"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "k8s.io/api/batch/v1"
 	"net/http"
 )
 
@@ -14,46 +13,64 @@ type GetK8sCronJobsHandler struct {
 	NS   string
 }
 
-func (h *GetK8sCronJobsHandler) ServeHTTP(c echo.Context) error {
-	type Response struct {
-		ID       string   `json:"id"`
-		Name     string   `json:"name"`
-		Schedule string   `json:"schedule"`
-		Age      string   `json:"age"`
-		Labels   []string `json:"labels"`
-	}
+type CronJobRow struct {
+	ID             string   `json:"id"`
+	Name           string   `json:"name"`
+	Schedule       string   `json:"schedule"`
+	Suspend        bool     `json:"suspend"`
+	Active         int      `json:"active"`
+	LastSchedule   string   `json:"last_schedule"`
+	LastSuccessful string   `json:"last_successful"`
+	Age            string   `json:"age"`
+	Labels         []string `json:"labels"`
+}
 
+func (h *GetK8sCronJobsHandler) ServeHTTP(c echo.Context) error {
 	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "GetK8sCronJobsHandler")
 	if err != nil {
 		logger.Warnf("%s: %v", errMsg, err)
 		return c.NoContent(http.StatusInternalServerError)
 	}
 
-	cronJobs, err := clientset.BatchV1().CronJobs(h.NS).List(context.TODO(), metav1.ListOptions{})
+	cronJobs, err := ListCronJobs(clientset, h.ID, h.Name, h.NS)
 	if err != nil {
 		logger.Warnf("Failed to get cron jobs when calling GetK8sCronJobsHandler: %v", err)
 		return c.NoContent(http.StatusInternalServerError)
 	}
 
-	var response = make([]Response, 0)
-	for _, cronJob := range cronJobs.Items {
-		name := cronJob.GenerateName + cronJob.Name
+	var response = make([]CronJobRow, 0)
+	for _, cronJob := range cronJobs {
+		response = append(response, NewCronJobRow(cronJob))
+	}
 
-		age := cronJob.GetObjectMeta().GetCreationTimestamp()
+	return c.JSON(http.StatusOK, response)
+}
 
-		labels := make([]string, 0)
-		for key, value := range cronJob.GetLabels() {
-			labels = append(labels, key+":"+value)
-		}
+func NewCronJobRow(cronJob v1.CronJob) CronJobRow {
+	name := cronJob.GenerateName + cronJob.Name
 
-		response = append(response, Response{
-			ID:       GenerateRandomString(10),
-			Name:     name,
-			Schedule: cronJob.Spec.Schedule,
-			Age:      ElapsedTimeShort(age.Time),
-			Labels:   labels,
-		})
+	age := cronJob.GetObjectMeta().GetCreationTimestamp()
+
+	labels := make([]string, 0)
+	for key, value := range cronJob.GetLabels() {
+		labels = append(labels, key+":"+value)
 	}
 
-	return c.JSON(http.StatusOK, response)
+	row := CronJobRow{
+		ID:       GenerateRandomString(10),
+		Name:     name,
+		Schedule: cronJob.Spec.Schedule,
+		Suspend:  cronJob.Spec.Suspend != nil && *cronJob.Spec.Suspend,
+		Active:   len(cronJob.Status.Active),
+		Age:      ElapsedTimeShort(age.Time),
+		Labels:   labels,
+	}
+	if cronJob.Status.LastScheduleTime != nil {
+		row.LastSchedule = ElapsedTimeShort(cronJob.Status.LastScheduleTime.Time)
+	}
+	if cronJob.Status.LastSuccessfulTime != nil {
+		row.LastSuccessful = ElapsedTimeShort(cronJob.Status.LastSuccessfulTime.Time)
+	}
+
+	return row
 }
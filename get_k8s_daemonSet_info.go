@@ -0,0 +1,203 @@
+package main
+
+import (
+	"context"
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	v1core "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1helpers "k8s.io/component-helpers/scheduling/corev1"
+	"k8s.io/component-helpers/scheduling/corev1/nodeaffinity"
+	"net/http"
+	"time"
+)
+
+type GetK8sDaemonSetInfoHandler struct {
+	ID        string
+	Name      string
+	NS        string
+	DaemonSet string
+}
+
+// daemonSetTolerations are added by the DaemonSet controller to every daemon pod
+var daemonSetTolerations = []v1core.Toleration{
+	{Key: v1core.TaintNodeNotReady, Operator: v1core.TolerationOpExists, Effect: v1core.TaintEffectNoExecute},
+	{Key: v1core.TaintNodeUnreachable, Operator: v1core.TolerationOpExists, Effect: v1core.TaintEffectNoExecute},
+	{Key: v1core.TaintNodeDiskPressure, Operator: v1core.TolerationOpExists, Effect: v1core.TaintEffectNoSchedule},
+	{Key: v1core.TaintNodeMemoryPressure, Operator: v1core.TolerationOpExists, Effect: v1core.TaintEffectNoSchedule},
+	{Key: v1core.TaintNodePIDPressure, Operator: v1core.TolerationOpExists, Effect: v1core.TaintEffectNoSchedule},
+	{Key: v1core.TaintNodeUnschedulable, Operator: v1core.TolerationOpExists, Effect: v1core.TaintEffectNoSchedule},
+}
+
+func (h *GetK8sDaemonSetInfoHandler) ServeHTTP(c echo.Context) error {
+	type Configuration struct {
+		UpdateStrategy  string   `json:"update_strategy"`
+		MaxUnavailable  string   `json:"max_unavailable"`
+		MaxSurge        string   `json:"max_surge"`
+		MinReadySeconds int32    `json:"min_ready_seconds"`
+		NodeSelector    []string `json:"node_selector"`
+	}
+	type Status struct {
+		DesiredNumberScheduled int32 `json:"desired_number_scheduled"`
+		CurrentNumberScheduled int32 `json:"current_number_scheduled"`
+		NumberReady            int32 `json:"number_ready"`
+		UpdatedNumberScheduled int32 `json:"updated_number_scheduled"`
+		NumberAvailable        int32 `json:"number_available"`
+		NumberMisscheduled     int32 `json:"number_misscheduled"`
+	}
+	type NodeCoverage struct {
+		Node     string `json:"node"`
+		Eligible bool   `json:"eligible"`
+		Reason   string `json:"reason"`
+		Pod      string `json:"pod"`
+		Ready    bool   `json:"ready"`
+	}
+	type Coverage struct {
+		Nodes    int            `json:"nodes"`
+		Eligible int            `json:"eligible"`
+		Running  int            `json:"running"`
+		Missing  []string       `json:"missing"`
+		PerNode  []NodeCoverage `json:"per_node"`
+	}
+	type Summary struct {
+		Configuration Configuration      `json:"configuration"`
+		Status        Status             `json:"status"`
+		PodSelectors  []string           `json:"pod_selectors"`
+		Coverage      Coverage           `json:"coverage"`
+		Pods          []SummaryPod       `json:"pods"`
+		Template      []SummaryContainer `json:"template"`
+		Volumes       []SummaryVolume    `json:"volumes"`
+		Conditions    []SummaryCondition `json:"conditions"`
+		Events        []ObjectEvent      `json:"events"`
+	}
+	type Response struct {
+		Summary  Summary          `json:"summary"`
+		Metadata ResourceMetadata `json:"metadata"`
+		YAML     string           `json:"yaml"`
+	}
+
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "GetK8sDaemonSetInfoHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	daemonSet, err := clientset.AppsV1().DaemonSets(h.NS).Get(context.Background(), h.DaemonSet, v1.GetOptions{})
+	if err != nil {
+		logger.Warnf("Failed to get daemon set when calling GetK8sDaemonSetInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	yamlStr, err := ResourceYAML(daemonSet, "apps/v1", "DaemonSet")
+	if err != nil {
+		logger.Warnf("Error marshaling to YAML when calling GetK8sDaemonSetInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	configuration := Configuration{
+		UpdateStrategy:  string(daemonSet.Spec.UpdateStrategy.Type),
+		MinReadySeconds: daemonSet.Spec.MinReadySeconds,
+		NodeSelector:    FormatKeyValues(daemonSet.Spec.Template.Spec.NodeSelector),
+	}
+	if rollingUpdate := daemonSet.Spec.UpdateStrategy.RollingUpdate; rollingUpdate != nil {
+		if rollingUpdate.MaxUnavailable != nil {
+			configuration.MaxUnavailable = rollingUpdate.MaxUnavailable.String()
+		}
+		if rollingUpdate.MaxSurge != nil {
+			configuration.MaxSurge = rollingUpdate.MaxSurge.String()
+		}
+	}
+
+	summary := Summary{
+		Configuration: configuration,
+		Status: Status{
+			DesiredNumberScheduled: daemonSet.Status.DesiredNumberScheduled,
+			CurrentNumberScheduled: daemonSet.Status.CurrentNumberScheduled,
+			NumberReady:            daemonSet.Status.NumberReady,
+			UpdatedNumberScheduled: daemonSet.Status.UpdatedNumberScheduled,
+			NumberAvailable:        daemonSet.Status.NumberAvailable,
+			NumberMisscheduled:     daemonSet.Status.NumberMisscheduled,
+		},
+		PodSelectors: FormatKeyValues(daemonSet.Spec.Selector.MatchLabels),
+		Coverage: Coverage{
+			Missing: make([]string, 0),
+			PerNode: make([]NodeCoverage, 0),
+		},
+		Template:   NewSummaryContainers(daemonSet.Spec.Template.Spec.Containers),
+		Volumes:    NewSummaryVolumes(daemonSet.Spec.Template.Spec.Volumes),
+		Conditions: make([]SummaryCondition, 0),
+	}
+
+	pods, err := ListSelectedPods(clientset, h.NS, daemonSet.Spec.Selector)
+	if err != nil {
+		logger.Warnf("Failed to get pods when calling GetK8sDaemonSetInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+	pods = ControlledPods(pods, daemonSet)
+	summary.Pods = NewSummaryPods(pods)
+
+	nodes, err := clientset.CoreV1().Nodes().List(context.Background(), v1.ListOptions{})
+	if err != nil {
+		logger.Warnf("Failed to get nodes when calling GetK8sDaemonSetInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	podsByNode := make(map[string]v1core.Pod)
+	for _, pod := range pods {
+		if pod.Spec.NodeName != "" && IsPodActive(pod) {
+			podsByNode[pod.Spec.NodeName] = pod
+		}
+	}
+
+	// A daemon pod is expected on every node matching its required node affinity whose taints it tolerates
+	templatePod := &v1core.Pod{Spec: daemonSet.Spec.Template.Spec}
+	requiredAffinity := nodeaffinity.GetRequiredNodeAffinity(templatePod)
+	tolerations := append(append([]v1core.Toleration{}, daemonSet.Spec.Template.Spec.Tolerations...), daemonSetTolerations...)
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		coverage := NodeCoverage{
+			Node:     node.Name,
+			Eligible: true,
+		}
+		if match, err := requiredAffinity.Match(node); err != nil || !match {
+			coverage.Eligible = false
+			coverage.Reason = "node selector or affinity does not match"
+		} else if taint, untolerated := corev1helpers.FindMatchingUntoleratedTaint(node.Spec.Taints, tolerations, func(t *v1core.Taint) bool {
+			return t.Effect == v1core.TaintEffectNoSchedule || t.Effect == v1core.TaintEffectNoExecute
+		}); untolerated {
+			coverage.Eligible = false
+			coverage.Reason = "taint not tolerated: " + FormatTaint(taint)
+		}
+
+		if pod, ok := podsByNode[node.Name]; ok {
+			coverage.Pod = pod.Name
+			coverage.Ready = NewSummaryPod(pod).Ready == len(pod.Spec.Containers)
+			summary.Coverage.Running++
+		} else if coverage.Eligible {
+			summary.Coverage.Missing = append(summary.Coverage.Missing, node.Name)
+		}
+		if coverage.Eligible {
+			summary.Coverage.Eligible++
+		}
+		summary.Coverage.PerNode = append(summary.Coverage.PerNode, coverage)
+	}
+	summary.Coverage.Nodes = len(nodes.Items)
+
+	for _, condition := range daemonSet.Status.Conditions {
+		summary.Conditions = append(summary.Conditions, NewSummaryCondition(string(condition.Type), string(condition.Status),
+			condition.Reason, condition.Message, time.Time{}, condition.LastTransitionTime.Time))
+	}
+
+	summary.Events, err = CollectObjectEvents(clientset, h.NS, "DaemonSet", h.DaemonSet)
+	if err != nil {
+		logger.Warnf("Failed to get events when calling GetK8sDaemonSetInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	response := Response{
+		Summary:  summary,
+		Metadata: NewResourceMetadata(daemonSet),
+		YAML:     yamlStr,
+	}
+	return c.JSON(http.StatusOK, response)
+}
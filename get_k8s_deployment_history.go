@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
+	"strconv"
+)
+
+type GetK8sDeploymentHistoryHandler struct {
+	ID         string
+	Name       string
+	NS         string
+	Deployment string
+}
+
+func (h *GetK8sDeploymentHistoryHandler) ServeHTTP(c echo.Context) error {
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "GetK8sDeploymentHistoryHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	deployment, err := clientset.AppsV1().Deployments(h.NS).Get(context.Background(), h.Deployment, v1.GetOptions{})
+	if err != nil {
+		logger.Warnf("Failed to get deployment when calling GetK8sDeploymentHistoryHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	replicaSets, err := DeploymentReplicaSets(clientset, deployment)
+	if err != nil {
+		logger.Warnf("Failed to get replica sets when calling GetK8sDeploymentHistoryHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	currentRevision, _ := strconv.ParseInt(deployment.Annotations[RevisionAnnotation], 10, 64)
+	var response = make([]DeploymentRevision, 0)
+	for _, rs := range replicaSets {
+		response = append(response, NewDeploymentRevision(rs, currentRevision))
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
+	"strconv"
+)
+
+// GetK8sDeploymentRevisionDiffHandler diffs the pod templates of two revisions,
+// by default the previous revision against the current one
+type GetK8sDeploymentRevisionDiffHandler struct {
+	ID         string
+	Name       string
+	NS         string
+	Deployment string
+	From       string
+	To         string
+}
+
+func (h *GetK8sDeploymentRevisionDiffHandler) ServeHTTP(c echo.Context) error {
+	type Response struct {
+		From DeploymentRevision `json:"from"`
+		To   DeploymentRevision `json:"to"`
+		Diff string             `json:"diff"`
+	}
+
+	var from, to int64
+	var err error
+	if h.From != "" {
+		if from, err = strconv.ParseInt(h.From, 10, 64); err != nil || from <= 0 {
+			return c.NoContent(http.StatusBadRequest)
+		}
+	}
+	if h.To != "" {
+		if to, err = strconv.ParseInt(h.To, 10, 64); err != nil || to <= 0 {
+			return c.NoContent(http.StatusBadRequest)
+		}
+	}
+
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "GetK8sDeploymentRevisionDiffHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	deployment, err := clientset.AppsV1().Deployments(h.NS).Get(context.Background(), h.Deployment, v1.GetOptions{})
+	if err != nil {
+		logger.Warnf("Failed to get deployment when calling GetK8sDeploymentRevisionDiffHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	replicaSets, err := DeploymentReplicaSets(clientset, deployment)
+	if err != nil {
+		logger.Warnf("Failed to get replica sets when calling GetK8sDeploymentRevisionDiffHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	currentRevision, _ := strconv.ParseInt(deployment.Annotations[RevisionAnnotation], 10, 64)
+	if to == 0 {
+		to = currentRevision
+	}
+	toRS, err := FindRevision(replicaSets, to, currentRevision)
+	if err != nil {
+		logger.Warnf("Failed to find revision when calling GetK8sDeploymentRevisionDiffHandler: %v", err)
+		return c.NoContent(http.StatusNotFound)
+	}
+	// Without a from revision compare with the revision right before the target one
+	fromRS, err := FindRevision(replicaSets, from, ReplicaSetRevision(*toRS))
+	if err != nil {
+		logger.Warnf("Failed to find revision when calling GetK8sDeploymentRevisionDiffHandler: %v", err)
+		return c.NoContent(http.StatusNotFound)
+	}
+
+	diff, err := PodTemplateDiff(*fromRS, *toRS)
+	if err != nil {
+		logger.Warnf("Failed to diff pod templates when calling GetK8sDeploymentRevisionDiffHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	response := Response{
+		From: NewDeploymentRevision(*fromRS, currentRevision),
+		To:   NewDeploymentRevision(*toRS, currentRevision),
+		Diff: diff,
+	}
+	return c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
+)
+
+// GetK8sEffectiveRulesHandler lists what a User, Group or ServiceAccount may do, NS is the namespace of the service account
+type GetK8sEffectiveRulesHandler struct {
+	ID      string
+	Name    string
+	Kind    string
+	Subject string
+	NS      string
+}
+
+func (h *GetK8sEffectiveRulesHandler) ServeHTTP(c echo.Context) error {
+	switch h.Kind {
+	case rbacv1.UserKind, rbacv1.GroupKind:
+	case rbacv1.ServiceAccountKind:
+		if h.NS == "" {
+			logger.Warnf("Namespace of service account is required when calling GetK8sEffectiveRulesHandler")
+			return c.NoContent(http.StatusBadRequest)
+		}
+	default:
+		logger.Warnf("Unsupported subject kind %s when calling GetK8sEffectiveRulesHandler", h.Kind)
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "GetK8sEffectiveRulesHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	rbac, err := LoadRBAC(clientset, metav1.NamespaceAll)
+	if err != nil {
+		logger.Warnf("Failed to load RBAC objects when calling GetK8sEffectiveRulesHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	subject := RBACSubject{
+		Kind: h.Kind,
+		Name: h.Subject,
+	}
+	if h.Kind == rbacv1.ServiceAccountKind {
+		subject.Namespace = h.NS
+	}
+
+	return c.JSON(http.StatusOK, rbac.EffectiveRules(subject))
+}
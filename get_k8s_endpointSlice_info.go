@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
+)
+
+type GetK8sEndpointSliceInfoHandler struct {
+	ID            string
+	Name          string
+	NS            string
+	EndpointSlice string
+}
+
+func (h *GetK8sEndpointSliceInfoHandler) ServeHTTP(c echo.Context) error {
+	type Endpoint struct {
+		Addresses   []string `json:"addresses"`
+		Ready       bool     `json:"ready"`
+		Serving     bool     `json:"serving"`
+		Terminating bool     `json:"terminating"`
+		Target      string   `json:"target"`
+		Node        string   `json:"node"`
+		Zone        string   `json:"zone"`
+	}
+	type Summary struct {
+		Service        string                `json:"service"`
+		AddressType    string                `json:"address_type"`
+		Ports          []string              `json:"ports"`
+		EndpointsCount ServiceEndpointsCount `json:"endpoints_count"`
+		Endpoints      []Endpoint            `json:"endpoints"`
+	}
+	type Response struct {
+		Summary  Summary          `json:"summary"`
+		Metadata ResourceMetadata `json:"metadata"`
+		YAML     string           `json:"yaml"`
+	}
+
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "GetK8sEndpointSliceInfoHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	slice, err := clientset.DiscoveryV1().EndpointSlices(h.NS).Get(context.Background(), h.EndpointSlice, v1.GetOptions{})
+	if err != nil {
+		logger.Warnf("Failed to get endpoint slice when calling GetK8sEndpointSliceInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	row := NewEndpointSliceRow(*slice)
+	summary := Summary{
+		Service:        row.Service,
+		AddressType:    row.AddressType,
+		Ports:          row.Ports,
+		EndpointsCount: row.Endpoints,
+		Endpoints:      make([]Endpoint, 0),
+	}
+
+	for _, endpoint := range slice.Endpoints {
+		e := Endpoint{
+			Addresses:   endpoint.Addresses,
+			Ready:       endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready,
+			Serving:     endpoint.Conditions.Serving == nil || *endpoint.Conditions.Serving,
+			Terminating: endpoint.Conditions.Terminating != nil && *endpoint.Conditions.Terminating,
+		}
+		if endpoint.TargetRef != nil {
+			e.Target = endpoint.TargetRef.Kind + "/" + endpoint.TargetRef.Name
+		}
+		if endpoint.NodeName != nil {
+			e.Node = *endpoint.NodeName
+		}
+		if endpoint.Zone != nil {
+			e.Zone = *endpoint.Zone
+		}
+		summary.Endpoints = append(summary.Endpoints, e)
+	}
+
+	yamlStr, err := ResourceYAML(slice, "discovery.k8s.io/v1", "EndpointSlice")
+	if err != nil {
+		logger.Warnf("Error marshaling to YAML when calling GetK8sEndpointSliceInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	response := Response{
+		Summary:  summary,
+		Metadata: NewResourceMetadata(slice),
+		YAML:     yamlStr,
+	}
+	return c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
+)
+
+type GetK8sEndpointSlicesHandler struct {
+	ID   string
+	Name string
+	NS   string
+}
+
+type EndpointSliceRow struct {
+	ID          string                `json:"id"`
+	Name        string                `json:"name"`
+	Service     string                `json:"service"`
+	AddressType string                `json:"address_type"`
+	Ports       []string              `json:"ports"`
+	Endpoints   ServiceEndpointsCount `json:"endpoints"`
+	Age         string                `json:"age"`
+	Labels      []string              `json:"labels"`
+	Condition   RowCondition          `json:"condition"`
+}
+
+func (h *GetK8sEndpointSlicesHandler) ServeHTTP(c echo.Context) error {
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "GetK8sEndpointSlicesHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	endpointSlices, err := clientset.DiscoveryV1().EndpointSlices(h.NS).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		logger.Warnf("Failed to get endpoint slices when calling GetK8sEndpointSlicesHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	var response = make([]EndpointSliceRow, 0)
+	for _, slice := range endpointSlices.Items {
+		response = append(response, NewEndpointSliceRow(slice))
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func NewEndpointSliceRow(slice discoveryv1.EndpointSlice) EndpointSliceRow {
+	name := slice.GenerateName + slice.Name
+
+	age := slice.GetObjectMeta().GetCreationTimestamp()
+
+	ready, notReady := CountEndpoints([]discoveryv1.EndpointSlice{slice})
+
+	conditionOK := true
+	conditionMessage := "Endpoint Slice is OK"
+	if ready == 0 && notReady > 0 {
+		conditionOK = false
+		conditionMessage = "Endpoint Slice has no ready endpoints"
+	}
+
+	return EndpointSliceRow{
+		ID:          GenerateRandomString(10),
+		Name:        name,
+		Service:     slice.Labels[discoveryv1.LabelServiceName],
+		AddressType: string(slice.AddressType),
+		Ports:       FormatEndpointPorts(slice.Ports),
+		Endpoints: ServiceEndpointsCount{
+			Ready:    ready,
+			NotReady: notReady,
+		},
+		Age:    ElapsedTimeShort(age.Time),
+		Labels: FormatKeyValues(slice.GetLabels()),
+		Condition: RowCondition{
+			OK:      conditionOK,
+			Message: conditionMessage,
+		},
+	}
+}
+
+// CountEndpoints counts ready and not ready endpoints, an endpoint without the ready condition is ready
+func CountEndpoints(endpointSlices []discoveryv1.EndpointSlice) (ready int, notReady int) {
+	for _, slice := range endpointSlices {
+		for _, endpoint := range slice.Endpoints {
+			if endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready {
+				ready++
+			} else {
+				notReady++
+			}
+		}
+	}
+	return
+}
+
+func FormatEndpointPorts(ports []discoveryv1.EndpointPort) []string {
+	result := make([]string, 0)
+	for _, port := range ports {
+		formatted := ""
+		if port.Name != nil && *port.Name != "" {
+			formatted = *port.Name + ":"
+		}
+		if port.Port != nil {
+			formatted += fmt.Sprintf("%d", *port.Port)
+		}
+		if port.Protocol != nil {
+			formatted += "/" + string(*port.Protocol)
+		}
+		result = append(result, formatted)
+	}
+	return result
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	"net/http"
+)
+
+type GetK8sEventsHandler struct {
+	ID     string
+	Name   string
+	NS     string
+	Kind   string
+	Object string
+}
+
+func (h *GetK8sEventsHandler) ServeHTTP(c echo.Context) error {
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "GetK8sEventsHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	events, err := CollectObjectEvents(clientset, h.NS, h.Kind, h.Object)
+	if err != nil {
+		logger.Warnf("Failed to get events when calling GetK8sEventsHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	return c.JSON(http.StatusOK, events)
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+	"net/http"
+	"time"
+)
+
+type GetK8sHelmReleaseInfoHandler struct {
+	ID      string
+	Name    string
+	NS      string
+	Release string
+}
+
+func (h *GetK8sHelmReleaseInfoHandler) ServeHTTP(c echo.Context) error {
+	type Summary struct {
+		Chart         string `json:"chart"`
+		ChartVersion  string `json:"chart_version"`
+		AppVersion    string `json:"app_version"`
+		Description   string `json:"description"`
+		Icon          string `json:"icon"`
+		Revision      int    `json:"revision"`
+		Status        string `json:"status"`
+		StatusMessage string `json:"status_message"`
+		FirstDeployed string `json:"first_deployed"`
+		LastDeployed  string `json:"last_deployed"`
+		Notes         string `json:"notes"`
+		Storage       string `json:"storage"`
+	}
+	type Revision struct {
+		Revision     int    `json:"revision"`
+		Status       string `json:"status"`
+		Chart        string `json:"chart"`
+		ChartVersion string `json:"chart_version"`
+		AppVersion   string `json:"app_version"`
+		Description  string `json:"description"`
+		Updated      string `json:"updated"`
+	}
+	type Response struct {
+		Summary     Summary                `json:"summary"`
+		Values      string                 `json:"values"`
+		ChartValues string                 `json:"chart_values"`
+		Manifest    string                 `json:"manifest"`
+		Resources   []HelmManifestResource `json:"resources"`
+		History     []Revision             `json:"history"`
+	}
+
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "GetK8sHelmReleaseInfoHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	releases, err := HelmReleaseHistory(clientset, h.NS, h.Release)
+	if err != nil {
+		logger.Warnf("Failed to get helm releases when calling GetK8sHelmReleaseInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	var release *HelmRelease
+	history := make([]Revision, 0)
+	for i := range releases {
+		if releases[i].Name != h.Release {
+			continue
+		}
+		release = &releases[i]
+		revision := Revision{
+			Revision:     release.Version,
+			Status:       release.Info.Status,
+			Chart:        release.Chart.Metadata.Name,
+			ChartVersion: release.Chart.Metadata.Version,
+			AppVersion:   release.Chart.Metadata.AppVersion,
+			Description:  release.Info.Description,
+		}
+		if !release.Info.LastDeployed.IsZero() {
+			revision.Updated = release.Info.LastDeployed.Format(time.RFC3339)
+		}
+		history = append(history, revision)
+	}
+	if release == nil {
+		logger.Warnf("Release %s was not found when calling GetK8sHelmReleaseInfoHandler", h.Release)
+		return c.NoContent(http.StatusNotFound)
+	}
+
+	if err := RedactHelmRelease(release); err != nil {
+		logger.Warnf("Failed to redact secrets when calling GetK8sHelmReleaseInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	values, err := yaml.Marshal(release.Config)
+	if err != nil {
+		logger.Warnf("Error marshaling values to YAML when calling GetK8sHelmReleaseInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+	chartValues, err := yaml.Marshal(release.Chart.Values)
+	if err != nil {
+		logger.Warnf("Error marshaling chart values to YAML when calling GetK8sHelmReleaseInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	resources, err := HelmManifestResources(release.Manifest, release.Namespace)
+	if err != nil {
+		logger.Warnf("Failed to parse manifest when calling GetK8sHelmReleaseInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	summary := Summary{
+		Chart:         release.Chart.Metadata.Name,
+		ChartVersion:  release.Chart.Metadata.Version,
+		AppVersion:    release.Chart.Metadata.AppVersion,
+		Description:   release.Chart.Metadata.Description,
+		Icon:          release.Chart.Metadata.Icon,
+		Revision:      release.Version,
+		Status:        release.Info.Status,
+		StatusMessage: release.Info.Description,
+		Notes:         release.Info.Notes,
+		Storage:       release.Storage,
+	}
+	if !release.Info.FirstDeployed.IsZero() {
+		summary.FirstDeployed = release.Info.FirstDeployed.Format(time.RFC3339)
+	}
+	if !release.Info.LastDeployed.IsZero() {
+		summary.LastDeployed = release.Info.LastDeployed.Format(time.RFC3339)
+	}
+
+	response := Response{
+		Summary:     summary,
+		Values:      string(values),
+		ChartValues: string(chartValues),
+		Manifest:    release.Manifest,
+		Resources:   resources,
+		History:     history,
+	}
+	return c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type GetK8sHelmReleasesHandler struct {
+	ID   string
+	Name string
+	NS   string
+}
+
+type HelmReleaseRow struct {
+	ID           string       `json:"id"`
+	Name         string       `json:"name"`
+	Namespace    string       `json:"namespace"`
+	Chart        string       `json:"chart"`
+	ChartVersion string       `json:"chart_version"`
+	AppVersion   string       `json:"app_version"`
+	Revision     int          `json:"revision"`
+	Status       string       `json:"status"`
+	LastDeployed string       `json:"last_deployed"`
+	Updated      string       `json:"updated"`
+	Storage      string       `json:"storage"`
+	Condition    RowCondition `json:"condition"`
+}
+
+func (h *GetK8sHelmReleasesHandler) ServeHTTP(c echo.Context) error {
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "GetK8sHelmReleasesHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	releases, err := ListHelmReleases(clientset, h.NS)
+	if err != nil {
+		logger.Warnf("Failed to get helm releases when calling GetK8sHelmReleasesHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	var response = make([]HelmReleaseRow, 0)
+	for _, release := range LatestHelmReleases(releases) {
+		response = append(response, NewHelmReleaseRow(release))
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func NewHelmReleaseRow(release HelmRelease) HelmReleaseRow {
+	row := HelmReleaseRow{
+		ID:           GenerateRandomString(10),
+		Name:         release.Name,
+		Namespace:    release.Namespace,
+		Chart:        release.Chart.Metadata.Name,
+		ChartVersion: release.Chart.Metadata.Version,
+		AppVersion:   release.Chart.Metadata.AppVersion,
+		Revision:     release.Version,
+		Status:       release.Info.Status,
+		Storage:      release.Storage,
+		Condition: RowCondition{
+			OK:      true,
+			Message: "Release is OK",
+		},
+	}
+	if !release.Info.LastDeployed.IsZero() {
+		row.LastDeployed = release.Info.LastDeployed.Format(time.RFC3339)
+		row.Updated = ElapsedTimeShort(release.Info.LastDeployed)
+	}
+	// Releases stuck in pending-install, pending-upgrade or pending-rollback block further upgrades
+	if release.Info.Status == "failed" || strings.HasPrefix(release.Info.Status, "pending-") {
+		row.Condition = RowCondition{
+			OK:      false,
+			Message: release.Info.Description,
+		}
+	}
+	return row
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
+)
+
+type GetK8sIngressInfoHandler struct {
+	ID      string
+	Name    string
+	NS      string
+	Ingress string
+}
+
+func (h *GetK8sIngressInfoHandler) ServeHTTP(c echo.Context) error {
+	type Rule struct {
+		Host     string `json:"host"`
+		Path     string `json:"path"`
+		PathType string `json:"path_type"`
+		Backend  string `json:"backend"`
+	}
+	type TLS struct {
+		Hosts      []string `json:"hosts"`
+		SecretName string   `json:"secret_name"`
+	}
+	type Summary struct {
+		Class          string   `json:"class"`
+		Addresses      []string `json:"addresses"`
+		DefaultBackend string   `json:"default_backend"`
+		Rules          []Rule   `json:"rules"`
+		TLS            []TLS    `json:"tls"`
+	}
+	type Response struct {
+		Summary  Summary          `json:"summary"`
+		Metadata ResourceMetadata `json:"metadata"`
+		Graph    *Graph           `json:"graph"`
+		YAML     string           `json:"yaml"`
+	}
+
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "GetK8sIngressInfoHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	ingress, err := clientset.NetworkingV1().Ingresses(h.NS).Get(context.Background(), h.Ingress, v1.GetOptions{})
+	if err != nil {
+		logger.Warnf("Failed to get ingress when calling GetK8sIngressInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	row := NewIngressRow(*ingress)
+	summary := Summary{
+		Class:     row.Class,
+		Addresses: row.Addresses,
+		Rules:     make([]Rule, 0),
+		TLS:       make([]TLS, 0),
+	}
+	if ingress.Spec.DefaultBackend != nil {
+		summary.DefaultBackend = FormatIngressBackend(*ingress.Spec.DefaultBackend)
+	}
+
+	for _, rule := range ingress.Spec.Rules {
+		host := rule.Host
+		if host == "" {
+			host = "*"
+		}
+		if rule.HTTP == nil {
+			summary.Rules = append(summary.Rules, Rule{Host: host})
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			r := Rule{
+				Host:    host,
+				Path:    path.Path,
+				Backend: FormatIngressBackend(path.Backend),
+			}
+			if path.PathType != nil {
+				r.PathType = string(*path.PathType)
+			}
+			summary.Rules = append(summary.Rules, r)
+		}
+	}
+
+	for _, tls := range ingress.Spec.TLS {
+		summary.TLS = append(summary.TLS, TLS{
+			Hosts:      tls.Hosts,
+			SecretName: tls.SecretName,
+		})
+	}
+
+	traverser := DAGTraverser{
+		Visited: make(map[string]bool),
+		Graph:   new(DAGTraverser).CreateGraph(),
+	}
+	if err := traverser.GenerateDAGForResource(clientset, KubernetesResource{ResourceName: h.Ingress, ResourceType: "Ingress", ResourceNamespace: h.NS}); err != nil {
+		logger.Warnf("Failed to get K8S resource traverse when calling GetK8sIngressInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	yamlStr, err := ResourceYAML(ingress, "networking.k8s.io/v1", "Ingress")
+	if err != nil {
+		logger.Warnf("Error marshaling to YAML when calling GetK8sIngressInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	response := Response{
+		Summary:  summary,
+		Metadata: NewResourceMetadata(ingress),
+		Graph:    traverser.Graph,
+		YAML:     yamlStr,
+	}
+	return c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
+)
+
+type GetK8sIngressesHandler struct {
+	ID   string
+	Name string
+	NS   string
+}
+
+type IngressRow struct {
+	ID         string       `json:"id"`
+	Name       string       `json:"name"`
+	Class      string       `json:"class"`
+	Hosts      []string     `json:"hosts"`
+	Paths      []string     `json:"paths"`
+	Backends   []string     `json:"backends"`
+	TLSSecrets []string     `json:"tls_secrets"`
+	Addresses  []string     `json:"addresses"`
+	Age        string       `json:"age"`
+	Labels     []string     `json:"labels"`
+	Condition  RowCondition `json:"condition"`
+}
+
+func (h *GetK8sIngressesHandler) ServeHTTP(c echo.Context) error {
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "GetK8sIngressesHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	ingresses, err := clientset.NetworkingV1().Ingresses(h.NS).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		logger.Warnf("Failed to get ingresses when calling GetK8sIngressesHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	var response = make([]IngressRow, 0)
+	for _, ingress := range ingresses.Items {
+		response = append(response, NewIngressRow(ingress))
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func NewIngressRow(ingress v1.Ingress) IngressRow {
+	name := ingress.GenerateName + ingress.Name
+
+	age := ingress.GetObjectMeta().GetCreationTimestamp()
+
+	hosts := make([]string, 0)
+	paths := make([]string, 0)
+	backends := make([]string, 0)
+	for _, rule := range ingress.Spec.Rules {
+		host := rule.Host
+		if host == "" {
+			host = "*"
+		}
+		hosts = append(hosts, host)
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			paths = append(paths, host+path.Path)
+			backends = append(backends, FormatIngressBackend(path.Backend))
+		}
+	}
+	if ingress.Spec.DefaultBackend != nil {
+		backends = append(backends, FormatIngressBackend(*ingress.Spec.DefaultBackend))
+	}
+
+	tlsSecrets := make([]string, 0)
+	for _, tls := range ingress.Spec.TLS {
+		if tls.SecretName != "" {
+			tlsSecrets = append(tlsSecrets, tls.SecretName)
+		}
+	}
+
+	addresses := make([]string, 0)
+	for _, lb := range ingress.Status.LoadBalancer.Ingress {
+		if lb.IP != "" {
+			addresses = append(addresses, lb.IP)
+		}
+		if lb.Hostname != "" {
+			addresses = append(addresses, lb.Hostname)
+		}
+	}
+
+	conditionOK := true
+	conditionMessage := "Ingress is OK"
+	if len(addresses) == 0 {
+		conditionOK = false
+		conditionMessage = "Ingress has no address assigned yet"
+	}
+
+	return IngressRow{
+		ID:         GenerateRandomString(10),
+		Name:       name,
+		Class:      IngressClass(ingress),
+		Hosts:      hosts,
+		Paths:      paths,
+		Backends:   backends,
+		TLSSecrets: tlsSecrets,
+		Addresses:  addresses,
+		Age:        ElapsedTimeShort(age.Time),
+		Labels:     FormatKeyValues(ingress.GetLabels()),
+		Condition: RowCondition{
+			OK:      conditionOK,
+			Message: conditionMessage,
+		},
+	}
+}
+
+// IngressClass returns the class from the spec or from the deprecated annotation
+func IngressClass(ingress v1.Ingress) string {
+	if ingress.Spec.IngressClassName != nil {
+		return *ingress.Spec.IngressClassName
+	}
+	return ingress.Annotations["kubernetes.io/ingress.class"]
+}
+
+func FormatIngressBackend(backend v1.IngressBackend) string {
+	if backend.Service != nil {
+		if backend.Service.Port.Name != "" {
+			return backend.Service.Name + ":" + backend.Service.Port.Name
+		}
+		return fmt.Sprintf("%s:%d", backend.Service.Name, backend.Service.Port.Number)
+	}
+	if backend.Resource != nil {
+		return backend.Resource.Kind + "/" + backend.Resource.Name
+	}
+	return ""
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"context"
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	batchv1 "k8s.io/api/batch/v1"
+	v1core "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
+	"time"
+)
+
+type GetK8sJobInfoHandler struct {
+	ID   string
+	Name string
+	NS   string
+	Job  string
+}
+
+func (h *GetK8sJobInfoHandler) ServeHTTP(c echo.Context) error {
+	type Configuration struct {
+		Completions             int32  `json:"completions"`
+		Parallelism             int32  `json:"parallelism"`
+		CompletionMode          string `json:"completion_mode"`
+		BackoffLimit            int32  `json:"backoff_limit"`
+		ActiveDeadlineSeconds   int64  `json:"active_deadline_seconds"`
+		TTLSecondsAfterFinished int32  `json:"ttl_seconds_after_finished"`
+		Suspend                 bool   `json:"suspend"`
+	}
+	type Status struct {
+		Active         int32  `json:"active"`
+		Ready          int32  `json:"ready"`
+		Succeeded      int32  `json:"succeeded"`
+		Failed         int32  `json:"failed"`
+		BackoffLeft    int32  `json:"backoff_left"`
+		StartTime      string `json:"start_time"`
+		CompletionTime string `json:"completion_time"`
+		Duration       string `json:"duration"`
+	}
+	type JobPod struct {
+		SummaryPod
+		Duration string `json:"duration"`
+		ExitCode int32  `json:"exit_code"`
+		Reason   string `json:"reason"`
+	}
+	type Summary struct {
+		Configuration Configuration      `json:"configuration"`
+		Status        Status             `json:"status"`
+		Pods          []JobPod           `json:"pods"`
+		Template      []SummaryContainer `json:"template"`
+		Volumes       []SummaryVolume    `json:"volumes"`
+		Conditions    []SummaryCondition `json:"conditions"`
+		Events        []ObjectEvent      `json:"events"`
+	}
+	type Response struct {
+		Summary  Summary          `json:"summary"`
+		Metadata ResourceMetadata `json:"metadata"`
+		YAML     string           `json:"yaml"`
+	}
+
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "GetK8sJobInfoHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	job, err := clientset.BatchV1().Jobs(h.NS).Get(context.Background(), h.Job, v1.GetOptions{})
+	if err != nil {
+		logger.Warnf("Failed to get job when calling GetK8sJobInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	yamlStr, err := ResourceYAML(job, "batch/v1", "Job")
+	if err != nil {
+		logger.Warnf("Error marshaling to YAML when calling GetK8sJobInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	configuration := Configuration{
+		CompletionMode: "NonIndexed",
+		// Defaults applied by the API server when the fields are omitted
+		Completions:  1,
+		Parallelism:  1,
+		BackoffLimit: 6,
+	}
+	if job.Spec.Completions != nil {
+		configuration.Completions = *job.Spec.Completions
+	}
+	if job.Spec.Parallelism != nil {
+		configuration.Parallelism = *job.Spec.Parallelism
+	}
+	if job.Spec.CompletionMode != nil {
+		configuration.CompletionMode = string(*job.Spec.CompletionMode)
+	}
+	if job.Spec.BackoffLimit != nil {
+		configuration.BackoffLimit = *job.Spec.BackoffLimit
+	}
+	if job.Spec.ActiveDeadlineSeconds != nil {
+		configuration.ActiveDeadlineSeconds = *job.Spec.ActiveDeadlineSeconds
+	}
+	if job.Spec.TTLSecondsAfterFinished != nil {
+		configuration.TTLSecondsAfterFinished = *job.Spec.TTLSecondsAfterFinished
+	}
+	if job.Spec.Suspend != nil {
+		configuration.Suspend = *job.Spec.Suspend
+	}
+
+	status := Status{
+		Active:      job.Status.Active,
+		Succeeded:   job.Status.Succeeded,
+		Failed:      job.Status.Failed,
+		BackoffLeft: configuration.BackoffLimit - job.Status.Failed,
+		Duration:    JobDuration(*job),
+	}
+	if job.Status.Ready != nil {
+		status.Ready = *job.Status.Ready
+	}
+	if status.BackoffLeft < 0 {
+		status.BackoffLeft = 0
+	}
+	if job.Status.StartTime != nil {
+		status.StartTime = job.Status.StartTime.Format(time.RFC3339)
+	}
+	if job.Status.CompletionTime != nil {
+		status.CompletionTime = job.Status.CompletionTime.Format(time.RFC3339)
+	}
+
+	summary := Summary{
+		Configuration: configuration,
+		Status:        status,
+		Pods:          make([]JobPod, 0),
+		Template:      NewSummaryContainers(job.Spec.Template.Spec.Containers),
+		Volumes:       NewSummaryVolumes(job.Spec.Template.Spec.Volumes),
+		Conditions:    make([]SummaryCondition, 0),
+	}
+
+	pods, err := ListSelectedPods(clientset, h.NS, job.Spec.Selector)
+	if err != nil {
+		logger.Warnf("Failed to get pods when calling GetK8sJobInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+	for _, pod := range ControlledPods(pods, job) {
+		jobPod := JobPod{
+			SummaryPod: NewSummaryPod(pod),
+			Duration:   podDuration(pod),
+		}
+		for _, containerStatus := range pod.Status.ContainerStatuses {
+			if terminated := containerStatus.State.Terminated; terminated != nil && terminated.ExitCode != 0 {
+				jobPod.ExitCode = terminated.ExitCode
+				jobPod.Reason = terminated.Reason
+			}
+		}
+		summary.Pods = append(summary.Pods, jobPod)
+	}
+
+	for _, condition := range job.Status.Conditions {
+		summary.Conditions = append(summary.Conditions, NewSummaryCondition(string(condition.Type), string(condition.Status),
+			condition.Reason, condition.Message, condition.LastProbeTime.Time, condition.LastTransitionTime.Time))
+	}
+
+	summary.Events, err = CollectObjectEvents(clientset, h.NS, "Job", h.Job)
+	if err != nil {
+		logger.Warnf("Failed to get events when calling GetK8sJobInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	response := Response{
+		Summary:  summary,
+		Metadata: NewResourceMetadata(job),
+		YAML:     yamlStr,
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+// JobDuration is the time a job ran for, or has been running for when not finished yet
+func JobDuration(job batchv1.Job) string {
+	if job.Status.StartTime == nil {
+		return ""
+	}
+	if job.Status.CompletionTime != nil {
+		return DurationTimeShort(job.Status.CompletionTime.Sub(job.Status.StartTime.Time))
+	}
+	return ElapsedTimeShort(job.Status.StartTime.Time)
+}
+
+func podDuration(pod v1core.Pod) string {
+	if pod.Status.StartTime == nil {
+		return ""
+	}
+	var finishedAt time.Time
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		terminated := containerStatus.State.Terminated
+		if terminated == nil {
+			return ElapsedTimeShort(pod.Status.StartTime.Time)
+		}
+		if terminated.FinishedAt.After(finishedAt) {
+			finishedAt = terminated.FinishedAt.Time
+		}
+	}
+	if finishedAt.IsZero() {
+		return ElapsedTimeShort(pod.Status.StartTime.Time)
+	}
+	return DurationTimeShort(finishedAt.Sub(pod.Status.StartTime.Time))
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"context"
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
+)
+
+type GetK8sNamespaceInfoHandler struct {
+	ID   string
+	Name string
+	NS   string
+}
+
+func (h *GetK8sNamespaceInfoHandler) ServeHTTP(c echo.Context) error {
+	type Quota struct {
+		Name      string          `json:"name"`
+		Scopes    []string        `json:"scopes"`
+		Resources []QuotaResource `json:"resources"`
+		Warning   bool            `json:"warning"`
+	}
+	type LimitRangeItem struct {
+		Type                 string   `json:"type"`
+		Default              []string `json:"default"`
+		DefaultRequest       []string `json:"default_request"`
+		Min                  []string `json:"min"`
+		Max                  []string `json:"max"`
+		MaxLimitRequestRatio []string `json:"max_limit_request_ratio"`
+	}
+	type LimitRange struct {
+		Name   string           `json:"name"`
+		Limits []LimitRangeItem `json:"limits"`
+	}
+	type WorkloadCount struct {
+		Kind  string `json:"kind"`
+		Count int    `json:"count"`
+	}
+	type Summary struct {
+		Status      string          `json:"status"`
+		Quotas      []Quota         `json:"quotas"`
+		LimitRanges []LimitRange    `json:"limit_ranges"`
+		Workloads   []WorkloadCount `json:"workloads"`
+	}
+	type Response struct {
+		Summary  Summary          `json:"summary"`
+		Metadata ResourceMetadata `json:"metadata"`
+		YAML     string           `json:"yaml"`
+	}
+
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "GetK8sNamespaceInfoHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	namespace, err := clientset.CoreV1().Namespaces().Get(context.Background(), h.NS, v1.GetOptions{})
+	if err != nil {
+		logger.Warnf("Failed to get namespace when calling GetK8sNamespaceInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	quotas, err := clientset.CoreV1().ResourceQuotas(h.NS).List(context.Background(), v1.ListOptions{})
+	if err != nil {
+		logger.Warnf("Failed to get resource quotas when calling GetK8sNamespaceInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	limitRanges, err := clientset.CoreV1().LimitRanges(h.NS).List(context.Background(), v1.ListOptions{})
+	if err != nil {
+		logger.Warnf("Failed to get limit ranges when calling GetK8sNamespaceInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	summary := Summary{
+		Status:      string(namespace.Status.Phase),
+		Quotas:      make([]Quota, 0),
+		LimitRanges: make([]LimitRange, 0),
+		Workloads:   make([]WorkloadCount, 0),
+	}
+
+	for _, quota := range quotas.Items {
+		q := Quota{
+			Name:      quota.Name,
+			Scopes:    make([]string, 0),
+			Resources: NewQuotaResources(quota),
+		}
+		for _, scope := range quota.Spec.Scopes {
+			q.Scopes = append(q.Scopes, string(scope))
+		}
+		for _, resource := range q.Resources {
+			if resource.Percent >= QuotaAlertPercent {
+				q.Warning = true
+			}
+		}
+		summary.Quotas = append(summary.Quotas, q)
+	}
+
+	for _, limitRange := range limitRanges.Items {
+		lr := LimitRange{
+			Name:   limitRange.Name,
+			Limits: make([]LimitRangeItem, 0),
+		}
+		for _, limit := range limitRange.Spec.Limits {
+			lr.Limits = append(lr.Limits, LimitRangeItem{
+				Type:                 string(limit.Type),
+				Default:              formatResourceList(limit.Default),
+				DefaultRequest:       formatResourceList(limit.DefaultRequest),
+				Min:                  formatResourceList(limit.Min),
+				Max:                  formatResourceList(limit.Max),
+				MaxLimitRequestRatio: formatResourceList(limit.MaxLimitRequestRatio),
+			})
+		}
+		summary.LimitRanges = append(summary.LimitRanges, lr)
+	}
+
+	counters := []struct {
+		kind  string
+		count func() (int, error)
+	}{
+		{"Deployment", func() (int, error) {
+			items, err := ListDeployments(clientset, h.ID, h.Name, h.NS)
+			return len(items), err
+		}},
+		{"StatefulSet", func() (int, error) {
+			items, err := ListStatefulSets(clientset, h.ID, h.Name, h.NS)
+			return len(items), err
+		}},
+		{"DaemonSet", func() (int, error) {
+			items, err := ListDaemonSets(clientset, h.ID, h.Name, h.NS)
+			return len(items), err
+		}},
+		{"ReplicaSet", func() (int, error) {
+			items, err := ListReplicaSets(clientset, h.ID, h.Name, h.NS)
+			return len(items), err
+		}},
+		{"ReplicationController", func() (int, error) {
+			items, err := ListReplicationControllers(clientset, h.ID, h.Name, h.NS)
+			return len(items), err
+		}},
+		{"Job", func() (int, error) {
+			items, err := ListJobs(clientset, h.ID, h.Name, h.NS)
+			return len(items), err
+		}},
+		{"CronJob", func() (int, error) {
+			items, err := ListCronJobs(clientset, h.ID, h.Name, h.NS)
+			return len(items), err
+		}},
+		{"Pod", func() (int, error) {
+			items, err := ListPods(clientset, h.ID, h.Name, h.NS)
+			return len(items), err
+		}},
+	}
+	for _, counter := range counters {
+		count, err := counter.count()
+		if err != nil {
+			logger.Warnf("Failed to count %s objects when calling GetK8sNamespaceInfoHandler: %v", counter.kind, err)
+			return c.NoContent(http.StatusInternalServerError)
+		}
+		summary.Workloads = append(summary.Workloads, WorkloadCount{Kind: counter.kind, Count: count})
+	}
+
+	yamlStr, err := ResourceYAML(namespace, "v1", "Namespace")
+	if err != nil {
+		logger.Warnf("Error marshaling to YAML when calling GetK8sNamespaceInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	response := Response{
+		Summary:  summary,
+		Metadata: NewResourceMetadata(namespace),
+		YAML:     yamlStr,
+	}
+	return c.JSON(http.StatusOK, response)
+}
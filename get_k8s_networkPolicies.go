@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
+)
+
+type GetK8sNetworkPoliciesHandler struct {
+	ID   string
+	Name string
+	NS   string
+}
+
+type NetworkPolicyRow struct {
+	ID           string       `json:"id"`
+	Name         string       `json:"name"`
+	PodSelector  string       `json:"pod_selector"`
+	PolicyTypes  []string     `json:"policy_types"`
+	IngressRules int          `json:"ingress_rules"`
+	EgressRules  int          `json:"egress_rules"`
+	Age          string       `json:"age"`
+	Labels       []string     `json:"labels"`
+	Condition    RowCondition `json:"condition"`
+}
+
+func (h *GetK8sNetworkPoliciesHandler) ServeHTTP(c echo.Context) error {
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "GetK8sNetworkPoliciesHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	networkPolicies, err := clientset.NetworkingV1().NetworkPolicies(h.NS).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		logger.Warnf("Failed to get network policies when calling GetK8sNetworkPoliciesHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	var response = make([]NetworkPolicyRow, 0)
+	for _, networkPolicy := range networkPolicies.Items {
+		response = append(response, NewNetworkPolicyRow(networkPolicy))
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func NewNetworkPolicyRow(networkPolicy v1.NetworkPolicy) NetworkPolicyRow {
+	name := networkPolicy.GenerateName + networkPolicy.Name
+
+	age := networkPolicy.GetObjectMeta().GetCreationTimestamp()
+
+	policyTypes := make([]string, 0)
+	for _, policyType := range networkPolicy.Spec.PolicyTypes {
+		policyTypes = append(policyTypes, string(policyType))
+	}
+
+	return NetworkPolicyRow{
+		ID:           GenerateRandomString(10),
+		Name:         name,
+		PodSelector:  FormatPodSelector(networkPolicy.Spec.PodSelector),
+		PolicyTypes:  policyTypes,
+		IngressRules: len(networkPolicy.Spec.Ingress),
+		EgressRules:  len(networkPolicy.Spec.Egress),
+		Age:          ElapsedTimeShort(age.Time),
+		Labels:       FormatKeyValues(networkPolicy.GetLabels()),
+		Condition: RowCondition{
+			OK:      true,
+			Message: "Network Policy is OK",
+		},
+	}
+}
+
+// FormatPodSelector formats a selector, an empty selector selects all pods of the namespace
+func FormatPodSelector(selector metav1.LabelSelector) string {
+	formatted := metav1.FormatLabelSelector(&selector)
+	if formatted == "" || formatted == "<none>" {
+		return "<all pods>"
+	}
+	return formatted
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	networkingv1 "k8s.io/api/networking/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
+	"strings"
+)
+
+type GetK8sNetworkPolicyInfoHandler struct {
+	ID            string
+	Name          string
+	NS            string
+	NetworkPolicy string
+}
+
+func (h *GetK8sNetworkPolicyInfoHandler) ServeHTTP(c echo.Context) error {
+	type Rule struct {
+		Peers []string `json:"peers"`
+		Ports []string `json:"ports"`
+	}
+	type Summary struct {
+		PodSelector  string   `json:"pod_selector"`
+		PolicyTypes  []string `json:"policy_types"`
+		Ingress      []Rule   `json:"ingress"`
+		Egress       []Rule   `json:"egress"`
+		SelectedPods []string `json:"selected_pods"`
+	}
+	type Response struct {
+		Summary  Summary          `json:"summary"`
+		Metadata ResourceMetadata `json:"metadata"`
+		YAML     string           `json:"yaml"`
+	}
+
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "GetK8sNetworkPolicyInfoHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	networkPolicy, err := clientset.NetworkingV1().NetworkPolicies(h.NS).Get(context.Background(), h.NetworkPolicy, v1.GetOptions{})
+	if err != nil {
+		logger.Warnf("Failed to get network policy when calling GetK8sNetworkPolicyInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	row := NewNetworkPolicyRow(*networkPolicy)
+	summary := Summary{
+		PodSelector:  row.PodSelector,
+		PolicyTypes:  row.PolicyTypes,
+		Ingress:      make([]Rule, 0),
+		Egress:       make([]Rule, 0),
+		SelectedPods: make([]string, 0),
+	}
+
+	for _, rule := range networkPolicy.Spec.Ingress {
+		summary.Ingress = append(summary.Ingress, Rule{
+			Peers: formatNetworkPolicyPeers(rule.From),
+			Ports: formatNetworkPolicyPorts(rule.Ports),
+		})
+	}
+	for _, rule := range networkPolicy.Spec.Egress {
+		summary.Egress = append(summary.Egress, Rule{
+			Peers: formatNetworkPolicyPeers(rule.To),
+			Ports: formatNetworkPolicyPorts(rule.Ports),
+		})
+	}
+
+	selector, err := v1.LabelSelectorAsSelector(&networkPolicy.Spec.PodSelector)
+	if err != nil {
+		logger.Warnf("Failed to parse pod selector when calling GetK8sNetworkPolicyInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+	pods, err := clientset.CoreV1().Pods(h.NS).List(context.Background(), v1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		logger.Warnf("Failed to get pods when calling GetK8sNetworkPolicyInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+	for _, pod := range pods.Items {
+		summary.SelectedPods = append(summary.SelectedPods, pod.Name)
+	}
+
+	yamlStr, err := ResourceYAML(networkPolicy, "networking.k8s.io/v1", "NetworkPolicy")
+	if err != nil {
+		logger.Warnf("Error marshaling to YAML when calling GetK8sNetworkPolicyInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	response := Response{
+		Summary:  summary,
+		Metadata: NewResourceMetadata(networkPolicy),
+		YAML:     yamlStr,
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+func formatNetworkPolicyPeers(peers []networkingv1.NetworkPolicyPeer) []string {
+	result := make([]string, 0)
+	if len(peers) == 0 {
+		// No peers means traffic is allowed from or to anywhere
+		return append(result, "<any>")
+	}
+	for _, peer := range peers {
+		if peer.IPBlock != nil {
+			formatted := "ipBlock: " + peer.IPBlock.CIDR
+			if len(peer.IPBlock.Except) > 0 {
+				formatted += " except " + strings.Join(peer.IPBlock.Except, ", ")
+			}
+			result = append(result, formatted)
+			continue
+		}
+		var parts []string
+		if peer.NamespaceSelector != nil {
+			namespaces := v1.FormatLabelSelector(peer.NamespaceSelector)
+			if namespaces == "<none>" {
+				namespaces = "<all namespaces>"
+			}
+			parts = append(parts, "namespaces: "+namespaces)
+		}
+		if peer.PodSelector != nil {
+			parts = append(parts, "pods: "+FormatPodSelector(*peer.PodSelector))
+		}
+		result = append(result, strings.Join(parts, ", "))
+	}
+	return result
+}
+
+func formatNetworkPolicyPorts(ports []networkingv1.NetworkPolicyPort) []string {
+	result := make([]string, 0)
+	if len(ports) == 0 {
+		return append(result, "<any>")
+	}
+	for _, port := range ports {
+		formatted := "<any>"
+		if port.Port != nil {
+			formatted = port.Port.String()
+			if port.EndPort != nil {
+				formatted += fmt.Sprintf("-%d", *port.EndPort)
+			}
+		}
+		if port.Protocol != nil {
+			formatted += "/" + string(*port.Protocol)
+		}
+		result = append(result, formatted)
+	}
+	return result
+}
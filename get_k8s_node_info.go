@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	v1core "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"net/http"
+)
+
+type GetK8sNodeInfoHandler struct {
+	ID   string
+	Name string
+	Node string
+}
+
+func (h *GetK8sNodeInfoHandler) ServeHTTP(c echo.Context) error {
+	type Condition struct {
+		Type           string `json:"type"`
+		Reason         string `json:"reason"`
+		Status         string `json:"status"`
+		Message        string `json:"message"`
+		LastHeartbeat  string `json:"last_heartbeat"`
+		LastTransition string `json:"last_transition"`
+	}
+	type Address struct {
+		Type    string `json:"type"`
+		Address string `json:"address"`
+	}
+	type System struct {
+		KubeletVersion   string `json:"kubelet_version"`
+		KubeProxyVersion string `json:"kube_proxy_version"`
+		OSImage          string `json:"os_image"`
+		KernelVersion    string `json:"kernel_version"`
+		ContainerRuntime string `json:"container_runtime"`
+		Architecture     string `json:"architecture"`
+	}
+	type Pod struct {
+		Name           string `json:"name"`
+		Namespace      string `json:"namespace"`
+		Phase          string `json:"phase"`
+		CPURequests    string `json:"cpu_requests"`
+		CPULimits      string `json:"cpu_limits"`
+		MemoryRequests string `json:"memory_requests"`
+		MemoryLimits   string `json:"memory_limits"`
+		Age            string `json:"age"`
+	}
+	type Summary struct {
+		Roles         []string           `json:"roles"`
+		Status        string             `json:"status"`
+		Unschedulable bool               `json:"unschedulable"`
+		Addresses     []Address          `json:"addresses"`
+		System        System             `json:"system"`
+		Conditions    []Condition        `json:"conditions"`
+		Taints        []string           `json:"taints"`
+		CPU           ResourceAllocation `json:"cpu"`
+		Memory        ResourceAllocation `json:"memory"`
+		Pods          NodePodsAllocation `json:"pods_allocation"`
+		PodsList      []Pod              `json:"pods"`
+	}
+	type Response struct {
+		Summary  Summary          `json:"summary"`
+		Metadata ResourceMetadata `json:"metadata"`
+		YAML     string           `json:"yaml"`
+	}
+
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "GetK8sNodeInfoHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	node, err := clientset.CoreV1().Nodes().Get(context.Background(), h.Node, v1.GetOptions{})
+	if err != nil {
+		logger.Warnf("Failed to get node when calling GetK8sNodeInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	pods, err := clientset.CoreV1().Pods(v1.NamespaceAll).List(context.Background(), v1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", node.Name).String(),
+	})
+	if err != nil {
+		logger.Warnf("Failed to get pods when calling GetK8sNodeInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+	activePods := make([]v1core.Pod, 0)
+	for _, pod := range pods.Items {
+		if IsPodActive(pod) {
+			activePods = append(activePods, pod)
+		}
+	}
+
+	row := NewNodeRow(*node, activePods)
+	summary := Summary{
+		Roles:         row.Roles,
+		Status:        row.Status,
+		Unschedulable: node.Spec.Unschedulable,
+		Addresses:     make([]Address, 0),
+		System: System{
+			KubeletVersion:   node.Status.NodeInfo.KubeletVersion,
+			KubeProxyVersion: node.Status.NodeInfo.KubeProxyVersion,
+			OSImage:          node.Status.NodeInfo.OSImage,
+			KernelVersion:    node.Status.NodeInfo.KernelVersion,
+			ContainerRuntime: node.Status.NodeInfo.ContainerRuntimeVersion,
+			Architecture:     node.Status.NodeInfo.Architecture,
+		},
+		Conditions: make([]Condition, 0),
+		Taints:     row.Taints,
+		CPU:        row.CPU,
+		Memory:     row.Memory,
+		Pods:       row.Pods,
+		PodsList:   make([]Pod, 0),
+	}
+
+	for _, address := range node.Status.Addresses {
+		summary.Addresses = append(summary.Addresses, Address{
+			Type:    string(address.Type),
+			Address: address.Address,
+		})
+	}
+
+	for _, condition := range node.Status.Conditions {
+		summary.Conditions = append(summary.Conditions, Condition{
+			Type:           string(condition.Type),
+			Reason:         condition.Reason,
+			Status:         string(condition.Status),
+			Message:        condition.Message,
+			LastHeartbeat:  ElapsedTimeShort(condition.LastHeartbeatTime.Time),
+			LastTransition: ElapsedTimeShort(condition.LastTransitionTime.Time),
+		})
+	}
+
+	// Finished pods are listed too, they just do not count towards the allocation
+	for _, pod := range pods.Items {
+		requests, limits := PodRequestsAndLimits(pod)
+		summary.PodsList = append(summary.PodsList, Pod{
+			Name:           pod.Name,
+			Namespace:      pod.Namespace,
+			Phase:          string(pod.Status.Phase),
+			CPURequests:    quantityString(requests, v1core.ResourceCPU),
+			CPULimits:      quantityString(limits, v1core.ResourceCPU),
+			MemoryRequests: quantityString(requests, v1core.ResourceMemory),
+			MemoryLimits:   quantityString(limits, v1core.ResourceMemory),
+			Age:            ElapsedTimeShort(pod.CreationTimestamp.Time),
+		})
+	}
+
+	yamlStr, err := ResourceYAML(node, "v1", "Node")
+	if err != nil {
+		logger.Warnf("Error marshaling to YAML when calling GetK8sNodeInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	response := Response{
+		Summary:  summary,
+		Metadata: NewResourceMetadata(node),
+		YAML:     yamlStr,
+	}
+	return c.JSON(http.StatusOK, response)
+}
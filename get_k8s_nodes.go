@@ -0,0 +1,204 @@
+package main
+
+import (
+	"context"
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+type GetK8sNodesHandler struct {
+	ID   string
+	Name string
+}
+
+type NodeConditionStatus struct {
+	Type   string `json:"type"`
+	Status string `json:"status"`
+}
+
+type NodePodsAllocation struct {
+	Capacity    int64 `json:"capacity"`
+	Allocatable int64 `json:"allocatable"`
+	Count       int   `json:"count"`
+}
+
+type NodeRow struct {
+	ID             string                `json:"id"`
+	Name           string                `json:"name"`
+	Roles          []string              `json:"roles"`
+	Status         string                `json:"status"`
+	KubeletVersion string                `json:"kubelet_version"`
+	OSImage        string                `json:"os_image"`
+	Conditions     []NodeConditionStatus `json:"conditions"`
+	Taints         []string              `json:"taints"`
+	CPU            ResourceAllocation    `json:"cpu"`
+	Memory         ResourceAllocation    `json:"memory"`
+	Pods           NodePodsAllocation    `json:"pods"`
+	Age            string                `json:"age"`
+	Labels         []string              `json:"labels"`
+	Condition      RowCondition          `json:"condition"`
+	Usage          ResourceUsage         `json:"usage"`
+}
+
+// NodeConditionTypes are the conditions shown for every node, in this order
+var NodeConditionTypes = []v1.NodeConditionType{v1.NodeReady, v1.NodeMemoryPressure, v1.NodeDiskPressure, v1.NodePIDPressure}
+
+func (h *GetK8sNodesHandler) ServeHTTP(c echo.Context) error {
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "GetK8sNodesHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	nodes, err := clientset.CoreV1().Nodes().List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		logger.Warnf("Failed to get nodes when calling GetK8sNodesHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	pods, err := ListPods(clientset, h.ID, h.Name, metav1.NamespaceAll)
+	if err != nil {
+		logger.Warnf("Failed to get pods when calling GetK8sNodesHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+	nodePods := make(map[string][]v1.Pod)
+	for _, pod := range pods {
+		if pod.Spec.NodeName != "" && IsPodActive(pod) {
+			nodePods[pod.Spec.NodeName] = append(nodePods[pod.Spec.NodeName], pod)
+		}
+	}
+
+	usages, _ := NodeUsages(h.ID, h.Name)
+
+	var response = make([]NodeRow, 0)
+	for _, node := range nodes.Items {
+		row := NewNodeRow(node, nodePods[node.Name])
+		requests, limits := v1.ResourceList{}, v1.ResourceList{}
+		for _, pod := range nodePods[node.Name] {
+			podRequests, podLimits := PodRequestsAndLimits(pod)
+			addResourceList(requests, podRequests)
+			addResourceList(limits, podLimits)
+		}
+		row.Usage = NewResourceUsage(usages[node.Name], requests, limits, node.Status.Allocatable)
+		response = append(response, row)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func NewNodeRow(node v1.Node, pods []v1.Pod) NodeRow {
+	name := node.GenerateName + node.Name
+
+	age := node.GetObjectMeta().GetCreationTimestamp()
+
+	conditions := make([]NodeConditionStatus, 0)
+	for _, conditionType := range NodeConditionTypes {
+		status := v1.ConditionUnknown
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == conditionType {
+				status = condition.Status
+			}
+		}
+		conditions = append(conditions, NodeConditionStatus{
+			Type:   string(conditionType),
+			Status: string(status),
+		})
+	}
+
+	taints := make([]string, 0)
+	for _, taint := range node.Spec.Taints {
+		taints = append(taints, FormatTaint(taint))
+	}
+
+	requests, limits := v1.ResourceList{}, v1.ResourceList{}
+	for _, pod := range pods {
+		podRequests, podLimits := PodRequestsAndLimits(pod)
+		addResourceList(requests, podRequests)
+		addResourceList(limits, podLimits)
+	}
+
+	status, conditionOK, conditionMessage := NodeStatus(node)
+
+	return NodeRow{
+		ID:             GenerateRandomString(10),
+		Name:           name,
+		Roles:          NodeRoles(node),
+		Status:         status,
+		KubeletVersion: node.Status.NodeInfo.KubeletVersion,
+		OSImage:        node.Status.NodeInfo.OSImage,
+		Conditions:     conditions,
+		Taints:         taints,
+		CPU:            NewResourceAllocation(v1.ResourceCPU, node.Status.Capacity, node.Status.Allocatable, requests, limits),
+		Memory:         NewResourceAllocation(v1.ResourceMemory, node.Status.Capacity, node.Status.Allocatable, requests, limits),
+		Pods: NodePodsAllocation{
+			Capacity:    node.Status.Capacity.Pods().Value(),
+			Allocatable: node.Status.Allocatable.Pods().Value(),
+			Count:       len(pods),
+		},
+		Age:    ElapsedTimeShort(age.Time),
+		Labels: FormatKeyValues(node.GetLabels()),
+		Condition: RowCondition{
+			OK:      conditionOK,
+			Message: conditionMessage,
+		},
+	}
+}
+
+// NodeStatus summarises a node like kubectl does, e.g. "Ready,SchedulingDisabled",
+// and reports the first pressure condition as the reason when the node is not healthy
+func NodeStatus(node v1.Node) (status string, ok bool, message string) {
+	status = "Unknown"
+	ok = false
+	message = "Node has not reported its status"
+	for _, condition := range node.Status.Conditions {
+		if condition.Type != v1.NodeReady {
+			continue
+		}
+		if condition.Status == v1.ConditionTrue {
+			status, ok, message = "Ready", true, "Node is OK"
+		} else {
+			status, message = "NotReady", condition.Message
+		}
+	}
+	for _, condition := range node.Status.Conditions {
+		if condition.Type != v1.NodeReady && condition.Status == v1.ConditionTrue {
+			ok = false
+			message = condition.Message
+			break
+		}
+	}
+	if node.Spec.Unschedulable {
+		status += ",SchedulingDisabled"
+	}
+	return
+}
+
+func NodeRoles(node v1.Node) []string {
+	roles := make([]string, 0)
+	for key, value := range node.Labels {
+		if strings.HasPrefix(key, "node-role.kubernetes.io/") {
+			roles = append(roles, strings.TrimPrefix(key, "node-role.kubernetes.io/"))
+		} else if key == "kubernetes.io/role" && value != "" {
+			roles = append(roles, value)
+		}
+	}
+	sort.Strings(roles)
+	return roles
+}
+
+func FormatTaint(taint v1.Taint) string {
+	if taint.Value == "" {
+		return taint.Key + ":" + string(taint.Effect)
+	}
+	return taint.Key + "=" + taint.Value + ":" + string(taint.Effect)
+}
+
+// IsPodActive reports whether a pod still holds node resources
+func IsPodActive(pod v1.Pod) bool {
+	return pod.Status.Phase != v1.PodSucceeded && pod.Status.Phase != v1.PodFailed
+}
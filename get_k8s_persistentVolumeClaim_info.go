@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	v1core "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
+)
+
+type GetK8sPersistentVolumeClaimInfoHandler struct {
+	ID                    string
+	Name                  string
+	NS                    string
+	PersistentVolumeClaim string
+}
+
+func (h *GetK8sPersistentVolumeClaimInfoHandler) ServeHTTP(c echo.Context) error {
+	type Condition struct {
+		Type           string `json:"type"`
+		Reason         string `json:"reason"`
+		Status         string `json:"status"`
+		Message        string `json:"message"`
+		LastTransition string `json:"last_transition"`
+	}
+	type Summary struct {
+		Phase        string      `json:"phase"`
+		Capacity     string      `json:"capacity"`
+		Requested    string      `json:"requested"`
+		AccessModes  []string    `json:"access_modes"`
+		VolumeMode   string      `json:"volume_mode"`
+		Volume       string      `json:"volume"`
+		StorageClass string      `json:"storage_class"`
+		Conditions   []Condition `json:"conditions"`
+		UsedBy       []string    `json:"used_by"`
+	}
+	type Response struct {
+		Summary  Summary          `json:"summary"`
+		Metadata ResourceMetadata `json:"metadata"`
+		YAML     string           `json:"yaml"`
+	}
+
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "GetK8sPersistentVolumeClaimInfoHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	pvc, err := clientset.CoreV1().PersistentVolumeClaims(h.NS).Get(context.Background(), h.PersistentVolumeClaim, v1.GetOptions{})
+	if err != nil {
+		logger.Warnf("Failed to get persistent volume claim when calling GetK8sPersistentVolumeClaimInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	pods, err := ListPods(clientset, h.ID, h.Name, h.NS)
+	if err != nil {
+		logger.Warnf("Failed to get pods when calling GetK8sPersistentVolumeClaimInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	row := NewPersistentVolumeClaimRow(*pvc)
+	summary := Summary{
+		Phase:        row.Phase,
+		Capacity:     row.Capacity,
+		AccessModes:  row.AccessModes,
+		Volume:       row.Volume,
+		StorageClass: row.StorageClass,
+		Conditions:   make([]Condition, 0),
+		UsedBy:       PodsUsing(pods, "PersistentVolumeClaim", pvc.Name),
+	}
+	if requested, ok := pvc.Spec.Resources.Requests[v1core.ResourceStorage]; ok {
+		summary.Requested = requested.String()
+	}
+	if pvc.Spec.VolumeMode != nil {
+		summary.VolumeMode = string(*pvc.Spec.VolumeMode)
+	}
+
+	for _, condition := range pvc.Status.Conditions {
+		summary.Conditions = append(summary.Conditions, Condition{
+			Type:           string(condition.Type),
+			Reason:         condition.Reason,
+			Status:         string(condition.Status),
+			Message:        condition.Message,
+			LastTransition: ElapsedTimeShort(condition.LastTransitionTime.Time),
+		})
+	}
+
+	yamlStr, err := ResourceYAML(pvc, "v1", "PersistentVolumeClaim")
+	if err != nil {
+		logger.Warnf("Error marshaling to YAML when calling GetK8sPersistentVolumeClaimInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	response := Response{
+		Summary:  summary,
+		Metadata: NewResourceMetadata(pvc),
+		YAML:     yamlStr,
+	}
+	return c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
+)
+
+type GetK8sPersistentVolumeClaimsHandler struct {
+	ID   string
+	Name string
+	NS   string
+}
+
+type PersistentVolumeClaimRow struct {
+	ID           string       `json:"id"`
+	Name         string       `json:"name"`
+	Phase        string       `json:"phase"`
+	Capacity     string       `json:"capacity"`
+	AccessModes  []string     `json:"access_modes"`
+	Volume       string       `json:"volume"`
+	StorageClass string       `json:"storage_class"`
+	Age          string       `json:"age"`
+	Labels       []string     `json:"labels"`
+	Condition    RowCondition `json:"condition"`
+}
+
+func (h *GetK8sPersistentVolumeClaimsHandler) ServeHTTP(c echo.Context) error {
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "GetK8sPersistentVolumeClaimsHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	pvcs, err := clientset.CoreV1().PersistentVolumeClaims(h.NS).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		logger.Warnf("Failed to get persistent volume claims when calling GetK8sPersistentVolumeClaimsHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	var response = make([]PersistentVolumeClaimRow, 0)
+	for _, pvc := range pvcs.Items {
+		response = append(response, NewPersistentVolumeClaimRow(pvc))
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func NewPersistentVolumeClaimRow(pvc v1.PersistentVolumeClaim) PersistentVolumeClaimRow {
+	name := pvc.GenerateName + pvc.Name
+
+	age := pvc.GetObjectMeta().GetCreationTimestamp()
+
+	capacity := ""
+	if storage, ok := pvc.Status.Capacity[v1.ResourceStorage]; ok {
+		capacity = storage.String()
+	}
+
+	storageClass := ""
+	if pvc.Spec.StorageClassName != nil {
+		storageClass = *pvc.Spec.StorageClassName
+	}
+
+	conditionOK := pvc.Status.Phase == v1.ClaimBound
+	conditionMessage := "Persistent Volume Claim is OK"
+	if !conditionOK {
+		conditionMessage = "Persistent Volume Claim is " + string(pvc.Status.Phase)
+	}
+	// Claim conditions report operations in progress such as resizing
+	for _, condition := range pvc.Status.Conditions {
+		if condition.Status == v1.ConditionTrue && condition.Message != "" {
+			conditionMessage = condition.Message
+		}
+	}
+
+	return PersistentVolumeClaimRow{
+		ID:           GenerateRandomString(10),
+		Name:         name,
+		Phase:        string(pvc.Status.Phase),
+		Capacity:     capacity,
+		AccessModes:  FormatAccessModes(pvc.Spec.AccessModes),
+		Volume:       pvc.Spec.VolumeName,
+		StorageClass: storageClass,
+		Age:          ElapsedTimeShort(age.Time),
+		Labels:       FormatKeyValues(pvc.GetLabels()),
+		Condition: RowCondition{
+			OK:      conditionOK,
+			Message: conditionMessage,
+		},
+	}
+}
+
+func FormatAccessModes(accessModes []v1.PersistentVolumeAccessMode) []string {
+	result := make([]string, 0)
+	for _, mode := range accessModes {
+		result = append(result, string(mode))
+	}
+	return result
+}
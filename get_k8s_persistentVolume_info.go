@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
+)
+
+type GetK8sPersistentVolumeInfoHandler struct {
+	ID               string
+	Name             string
+	PersistentVolume string
+}
+
+func (h *GetK8sPersistentVolumeInfoHandler) ServeHTTP(c echo.Context) error {
+	type ClaimRef struct {
+		Namespace string `json:"namespace"`
+		Name      string `json:"name"`
+	}
+	type Source struct {
+		Kind        string `json:"kind"`
+		Description string `json:"description"`
+	}
+	type Summary struct {
+		Phase         string    `json:"phase"`
+		Capacity      string    `json:"capacity"`
+		AccessModes   []string  `json:"access_modes"`
+		ReclaimPolicy string    `json:"reclaim_policy"`
+		StorageClass  string    `json:"storage_class"`
+		VolumeMode    string    `json:"volume_mode"`
+		ClaimRef      *ClaimRef `json:"claim_ref"`
+		Source        Source    `json:"source"`
+		UsedBy        []string  `json:"used_by"`
+	}
+	type Response struct {
+		Summary  Summary          `json:"summary"`
+		Metadata ResourceMetadata `json:"metadata"`
+		YAML     string           `json:"yaml"`
+	}
+
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "GetK8sPersistentVolumeInfoHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	pv, err := clientset.CoreV1().PersistentVolumes().Get(context.Background(), h.PersistentVolume, v1.GetOptions{})
+	if err != nil {
+		logger.Warnf("Failed to get persistent volume when calling GetK8sPersistentVolumeInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	row := NewPersistentVolumeRow(*pv)
+	summary := Summary{
+		Phase:         row.Phase,
+		Capacity:      row.Capacity,
+		AccessModes:   row.AccessModes,
+		ReclaimPolicy: row.ReclaimPolicy,
+		StorageClass:  row.StorageClass,
+		UsedBy:        make([]string, 0),
+	}
+	if pv.Spec.VolumeMode != nil {
+		summary.VolumeMode = string(*pv.Spec.VolumeMode)
+	}
+
+	switch {
+	case pv.Spec.CSI != nil:
+		summary.Source = Source{Kind: "CSI", Description: pv.Spec.CSI.Driver + " " + pv.Spec.CSI.VolumeHandle}
+	case pv.Spec.HostPath != nil:
+		summary.Source = Source{Kind: "HostPath", Description: pv.Spec.HostPath.Path}
+	case pv.Spec.Local != nil:
+		summary.Source = Source{Kind: "Local", Description: pv.Spec.Local.Path}
+	case pv.Spec.NFS != nil:
+		summary.Source = Source{Kind: "NFS", Description: pv.Spec.NFS.Server + ":" + pv.Spec.NFS.Path}
+	default:
+		summary.Source = Source{Kind: "Other"}
+	}
+
+	// A volume is used by the pods that mount the claim it is bound to
+	if pv.Spec.ClaimRef != nil {
+		summary.ClaimRef = &ClaimRef{
+			Namespace: pv.Spec.ClaimRef.Namespace,
+			Name:      pv.Spec.ClaimRef.Name,
+		}
+		pods, err := ListPods(clientset, h.ID, h.Name, pv.Spec.ClaimRef.Namespace)
+		if err != nil {
+			logger.Warnf("Failed to get pods when calling GetK8sPersistentVolumeInfoHandler: %v", err)
+			return c.NoContent(http.StatusInternalServerError)
+		}
+		for _, pod := range PodsUsing(pods, "PersistentVolumeClaim", pv.Spec.ClaimRef.Name) {
+			summary.UsedBy = append(summary.UsedBy, pv.Spec.ClaimRef.Namespace+"/"+pod)
+		}
+	}
+
+	yamlStr, err := ResourceYAML(pv, "v1", "PersistentVolume")
+	if err != nil {
+		logger.Warnf("Error marshaling to YAML when calling GetK8sPersistentVolumeInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	response := Response{
+		Summary:  summary,
+		Metadata: NewResourceMetadata(pv),
+		YAML:     yamlStr,
+	}
+	return c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
+)
+
+type GetK8sPersistentVolumesHandler struct {
+	ID   string
+	Name string
+}
+
+type PersistentVolumeRow struct {
+	ID            string       `json:"id"`
+	Name          string       `json:"name"`
+	Phase         string       `json:"phase"`
+	Capacity      string       `json:"capacity"`
+	AccessModes   []string     `json:"access_modes"`
+	ReclaimPolicy string       `json:"reclaim_policy"`
+	Claim         string       `json:"claim"`
+	StorageClass  string       `json:"storage_class"`
+	Age           string       `json:"age"`
+	Labels        []string     `json:"labels"`
+	Condition     RowCondition `json:"condition"`
+}
+
+func (h *GetK8sPersistentVolumesHandler) ServeHTTP(c echo.Context) error {
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "GetK8sPersistentVolumesHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	pvs, err := clientset.CoreV1().PersistentVolumes().List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		logger.Warnf("Failed to get persistent volumes when calling GetK8sPersistentVolumesHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	var response = make([]PersistentVolumeRow, 0)
+	for _, pv := range pvs.Items {
+		response = append(response, NewPersistentVolumeRow(pv))
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func NewPersistentVolumeRow(pv v1.PersistentVolume) PersistentVolumeRow {
+	name := pv.GenerateName + pv.Name
+
+	age := pv.GetObjectMeta().GetCreationTimestamp()
+
+	capacity := ""
+	if storage, ok := pv.Spec.Capacity[v1.ResourceStorage]; ok {
+		capacity = storage.String()
+	}
+
+	claim := ""
+	if pv.Spec.ClaimRef != nil {
+		claim = pv.Spec.ClaimRef.Namespace + "/" + pv.Spec.ClaimRef.Name
+	}
+
+	conditionOK := pv.Status.Phase != v1.VolumeFailed
+	conditionMessage := pv.Status.Message
+	if conditionMessage == "" {
+		conditionMessage = "Persistent Volume is " + string(pv.Status.Phase)
+	}
+
+	return PersistentVolumeRow{
+		ID:            GenerateRandomString(10),
+		Name:          name,
+		Phase:         string(pv.Status.Phase),
+		Capacity:      capacity,
+		AccessModes:   FormatAccessModes(pv.Spec.AccessModes),
+		ReclaimPolicy: string(pv.Spec.PersistentVolumeReclaimPolicy),
+		Claim:         claim,
+		StorageClass:  pv.Spec.StorageClassName,
+		Age:           ElapsedTimeShort(age.Time),
+		Labels:        FormatKeyValues(pv.GetLabels()),
+		Condition: RowCondition{
+			OK:      conditionOK,
+			Message: conditionMessage,
+		},
+	}
+}
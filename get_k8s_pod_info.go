@@ -0,0 +1,223 @@
+package main
+
+import (
+	"context"
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	v1core "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
+	"time"
+)
+
+type GetK8sPodInfoHandler struct {
+	ID   string
+	Name string
+	NS   string
+	Pod  string
+}
+
+func (h *GetK8sPodInfoHandler) ServeHTTP(c echo.Context) error {
+	type Configuration struct {
+		Node               string   `json:"node"`
+		ServiceAccount     string   `json:"service_account"`
+		RestartPolicy      string   `json:"restart_policy"`
+		PriorityClass      string   `json:"priority_class"`
+		QOSClass           string   `json:"qos_class"`
+		ControlledBy       string   `json:"controlled_by"`
+		NodeSelector       []string `json:"node_selector"`
+		Tolerations        []string `json:"tolerations"`
+		PodIP              string   `json:"pod_ip"`
+		HostIP             string   `json:"host_ip"`
+		StartTime          string   `json:"start_time"`
+		TerminationGrace   int64    `json:"termination_grace"`
+		DeletionInProgress bool     `json:"deletion_in_progress"`
+	}
+	type ContainerProbes struct {
+		Liveness  string `json:"liveness"`
+		Readiness string `json:"readiness"`
+		Startup   string `json:"startup"`
+	}
+	type Container struct {
+		SummaryContainer
+		Init         bool            `json:"init"`
+		Ready        bool            `json:"ready"`
+		Started      bool            `json:"started"`
+		Restarts     int32           `json:"restarts"`
+		State        string          `json:"state"`
+		StateReason  string          `json:"state_reason"`
+		StateMessage string          `json:"state_message"`
+		LastState    string          `json:"last_state"`
+		Requests     []string        `json:"requests"`
+		Limits       []string        `json:"limits"`
+		Probes       ContainerProbes `json:"probes"`
+	}
+	type Summary struct {
+		Configuration Configuration      `json:"configuration"`
+		Status        SummaryPod         `json:"status"`
+		Containers    []Container        `json:"containers"`
+		Volumes       []SummaryVolume    `json:"volumes"`
+		Conditions    []SummaryCondition `json:"conditions"`
+		Events        []ObjectEvent      `json:"events"`
+	}
+	type Response struct {
+		Summary  Summary          `json:"summary"`
+		Metadata ResourceMetadata `json:"metadata"`
+		Graph    *Graph           `json:"graph"`
+		YAML     string           `json:"yaml"`
+	}
+
+	traverser := DAGTraverser{
+		Visited: make(map[string]bool),
+		Graph:   new(DAGTraverser).CreateGraph(),
+	}
+
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "GetK8sPodInfoHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	pod, err := clientset.CoreV1().Pods(h.NS).Get(context.Background(), h.Pod, v1.GetOptions{})
+	if err != nil {
+		logger.Warnf("Failed to get pod when calling GetK8sPodInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	yamlStr, err := ResourceYAML(pod, "v1", "Pod")
+	if err != nil {
+		logger.Warnf("Error marshaling to YAML when calling GetK8sPodInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	if err := traverser.GenerateDAGForResource(clientset, KubernetesResource{ResourceName: h.Pod, ResourceType: "Pod", ResourceNamespace: h.NS}); err != nil {
+		logger.Warnf("Failed to get K8S resource traverse when calling GetK8sPodInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	configuration := Configuration{
+		Node:               pod.Spec.NodeName,
+		ServiceAccount:     pod.Spec.ServiceAccountName,
+		RestartPolicy:      string(pod.Spec.RestartPolicy),
+		PriorityClass:      pod.Spec.PriorityClassName,
+		QOSClass:           string(pod.Status.QOSClass),
+		NodeSelector:       FormatKeyValues(pod.Spec.NodeSelector),
+		Tolerations:        make([]string, 0),
+		PodIP:              pod.Status.PodIP,
+		HostIP:             pod.Status.HostIP,
+		DeletionInProgress: pod.DeletionTimestamp != nil,
+	}
+	if owner := v1.GetControllerOf(pod); owner != nil {
+		configuration.ControlledBy = owner.Kind + "/" + owner.Name
+	}
+	if pod.Status.StartTime != nil {
+		configuration.StartTime = pod.Status.StartTime.Format(time.RFC3339)
+	}
+	if pod.Spec.TerminationGracePeriodSeconds != nil {
+		configuration.TerminationGrace = *pod.Spec.TerminationGracePeriodSeconds
+	}
+	for _, toleration := range pod.Spec.Tolerations {
+		configuration.Tolerations = append(configuration.Tolerations, formatToleration(toleration))
+	}
+
+	summary := Summary{
+		Configuration: configuration,
+		Status:        NewSummaryPod(*pod),
+		Containers:    make([]Container, 0),
+		Volumes:       NewSummaryVolumes(pod.Spec.Volumes),
+		Conditions:    make([]SummaryCondition, 0),
+	}
+
+	statuses := make(map[string]v1core.ContainerStatus)
+	for _, containerStatus := range append(append([]v1core.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...) {
+		statuses[containerStatus.Name] = containerStatus
+	}
+	addContainers := func(containers []v1core.Container, init bool) {
+		for _, container := range containers {
+			entry := Container{
+				SummaryContainer: NewSummaryContainer(container),
+				Init:             init,
+				Requests:         formatResourceList(container.Resources.Requests),
+				Limits:           formatResourceList(container.Resources.Limits),
+				Probes: ContainerProbes{
+					Liveness:  FormatProbe(container.LivenessProbe),
+					Readiness: FormatProbe(container.ReadinessProbe),
+					Startup:   FormatProbe(container.StartupProbe),
+				},
+				State: "Waiting",
+			}
+			if containerStatus, ok := statuses[container.Name]; ok {
+				entry.Ready = containerStatus.Ready
+				entry.Restarts = containerStatus.RestartCount
+				if containerStatus.Started != nil {
+					entry.Started = *containerStatus.Started
+				}
+				entry.State, entry.StateReason, entry.StateMessage = containerState(containerStatus.State)
+				if containerStatus.LastTerminationState.Terminated != nil {
+					lastState, lastReason, _ := containerState(containerStatus.LastTerminationState)
+					entry.LastState = lastState + ": " + lastReason
+				}
+			}
+			summary.Containers = append(summary.Containers, entry)
+		}
+	}
+	addContainers(pod.Spec.InitContainers, true)
+	addContainers(pod.Spec.Containers, false)
+
+	for _, condition := range pod.Status.Conditions {
+		summary.Conditions = append(summary.Conditions, NewSummaryCondition(string(condition.Type), string(condition.Status),
+			condition.Reason, condition.Message, condition.LastProbeTime.Time, condition.LastTransitionTime.Time))
+	}
+
+	summary.Events, err = CollectObjectEvents(clientset, h.NS, "Pod", h.Pod)
+	if err != nil {
+		logger.Warnf("Failed to get events when calling GetK8sPodInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	response := Response{
+		Summary:  summary,
+		Metadata: NewResourceMetadata(pod),
+		Graph:    traverser.Graph,
+		YAML:     yamlStr,
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+func containerState(state v1core.ContainerState) (name, reason, message string) {
+	switch {
+	case state.Running != nil:
+		return "Running", "", "Started " + ElapsedTimeShort(state.Running.StartedAt.Time) + " ago"
+	case state.Terminated != nil:
+		return "Terminated", state.Terminated.Reason, state.Terminated.Message
+	case state.Waiting != nil:
+		return "Waiting", state.Waiting.Reason, state.Waiting.Message
+	}
+	return "Waiting", "", ""
+}
+
+func formatToleration(toleration v1core.Toleration) string {
+	formatted := toleration.Key
+	if toleration.Operator == v1core.TolerationOpExists {
+		if formatted == "" {
+			formatted = "<all>"
+		}
+	} else if toleration.Value != "" {
+		formatted += "=" + toleration.Value
+	}
+	if toleration.Effect != "" {
+		formatted += ":" + string(toleration.Effect)
+	}
+	if toleration.TolerationSeconds != nil {
+		formatted += " for " + DurationTimeShort(time.Duration(*toleration.TolerationSeconds)*time.Second)
+	}
+	return formatted
+}
+
+func formatResourceList(list v1core.ResourceList) []string {
+	values := make(map[string]string)
+	for name, quantity := range list {
+		values[string(name)] = quantity.String()
+	}
+	return FormatKeyValues(values)
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
+)
+
+type GetK8sPodLogsHandler struct {
+	ID    string
+	Name  string
+	NS    string
+	Pod   string
+	Query LogQuery
+}
+
+func (h *GetK8sPodLogsHandler) ServeHTTP(c echo.Context) error {
+	options, err := h.Query.Options()
+	if err != nil {
+		logger.Warnf("Invalid log options when calling GetK8sPodLogsHandler: %v", err)
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "GetK8sPodLogsHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	container := options.Container
+	if container == "" {
+		pod, err := clientset.CoreV1().Pods(h.NS).Get(context.Background(), h.Pod, v1.GetOptions{})
+		if err != nil {
+			logger.Warnf("Failed to get pod when calling GetK8sPodLogsHandler: %v", err)
+			return c.NoContent(http.StatusInternalServerError)
+		}
+		container = DefaultContainer(*pod)
+	}
+	targets := []LogTarget{{Pod: h.Pod, Container: container}}
+
+	if options.Follow {
+		return FollowLogs(c, clientset, h.NS, targets, options, false)
+	}
+
+	lines, err := CollectLogs(c.Request().Context(), clientset, h.NS, targets, options, false)
+	if err != nil {
+		logger.Warnf("Failed to get logs when calling GetK8sPodLogsHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	return c.JSON(http.StatusOK, lines)
+}
@@ -1,11 +1,9 @@
 package main
 
 import (
-	"context"
 	"github.com/labstack/echo/v4"
 	logger "github.com/sirupsen/logrus"
 	v1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"net/http"
 	"strconv"
 )
@@ -16,91 +14,98 @@ type GetK8sPodsHandler struct {
 	NS   string
 }
 
-func (h *GetK8sPodsHandler) ServeHTTP(c echo.Context) error {
-	type Condition struct {
-		OK      bool   `json:"ok"`
-		Message string `json:"message"`
-	}
-	type Response struct {
-		ID           string    `json:"id"`
-		Name         string    `json:"name"`
-		ReadyActual  int       `json:"ready_actual"`
-		ReadyDesired int       `json:"ready_desired"`
-		Phase        string    `json:"phase"`
-		Status       string    `json:"status"`
-		Restarts     int       `json:"restarts"`
-		Node         string    `json:"node"`
-		Age          string    `json:"age"`
-		Labels       []string  `json:"labels"`
-		Condition    Condition `json:"condition"`
-	}
+type PodRow struct {
+	ID           string       `json:"id"`
+	Name         string       `json:"name"`
+	ReadyActual  int          `json:"ready_actual"`
+	ReadyDesired int          `json:"ready_desired"`
+	Phase        string       `json:"phase"`
+	Status       string       `json:"status"`
+	Restarts     int          `json:"restarts"`
+	Node         string       `json:"node"`
+	Age          string       `json:"age"`
+	Labels       []string     `json:"labels"`
+	Condition    RowCondition `json:"condition"`
+	// Usage is filled by the list handler, rows of the watch stream come without it
+	Usage ResourceUsage `json:"usage"`
+}
 
+func (h *GetK8sPodsHandler) ServeHTTP(c echo.Context) error {
 	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "GetK8sPodsHandler")
 	if err != nil {
 		logger.Warnf("%s: %v", errMsg, err)
 		return c.NoContent(http.StatusInternalServerError)
 	}
 
-	pods, err := clientset.CoreV1().Pods(h.NS).List(context.TODO(), metav1.ListOptions{})
+	pods, err := ListPods(clientset, h.ID, h.Name, h.NS)
 	if err != nil {
 		logger.Warnf("Failed to get pods when calling GetK8sPodsHandler: %v", err)
 		return c.NoContent(http.StatusInternalServerError)
 	}
 
-	var response = make([]Response, 0)
-	for _, pod := range pods.Items {
-		name := pod.GenerateName + pod.Name
+	usages, _ := PodUsages(h.ID, h.Name, h.NS)
 
-		age := pod.GetObjectMeta().GetCreationTimestamp()
+	var response = make([]PodRow, 0)
+	for _, pod := range pods {
+		row := NewPodRow(pod)
+		requests, limits := PodRequestsAndLimits(pod)
+		row.Usage = NewResourceUsage(usages[pod.Namespace+"/"+pod.Name], requests, limits, nil)
+		response = append(response, row)
+	}
 
-		labels := make([]string, 0)
-		for key, value := range pod.GetLabels() {
-			labels = append(labels, key+":"+value)
-		}
+	return c.JSON(http.StatusOK, response)
+}
 
-		readyContainers := 0
-		for _, status := range pod.Status.ContainerStatuses {
-			if status.Ready {
-				readyContainers++
-			}
+func NewPodRow(pod v1.Pod) PodRow {
+	name := pod.GenerateName + pod.Name
+
+	age := pod.GetObjectMeta().GetCreationTimestamp()
+
+	labels := make([]string, 0)
+	for key, value := range pod.GetLabels() {
+		labels = append(labels, key+":"+value)
+	}
+
+	readyContainers := 0
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Ready {
+			readyContainers++
 		}
-		var latestCondition v1.PodCondition
-		latestConditionOK := true
-		if len(pod.Status.Conditions) > 0 {
-			latestCondition = pod.Status.Conditions[0]
-			for _, condition := range pod.Status.Conditions {
-				if condition.LastTransitionTime.After(latestCondition.LastTransitionTime.Time) {
-					latestCondition = condition
-				}
+	}
+	var latestCondition v1.PodCondition
+	latestConditionOK := true
+	if len(pod.Status.Conditions) > 0 {
+		latestCondition = pod.Status.Conditions[0]
+		for _, condition := range pod.Status.Conditions {
+			if condition.LastTransitionTime.After(latestCondition.LastTransitionTime.Time) {
+				latestCondition = condition
 			}
-			latestConditionOK, _ = strconv.ParseBool(string(latestCondition.Status))
-		}
-		latestConditionMessage := latestCondition.Message
-		if latestCondition.Message == "" && latestConditionOK {
-			latestConditionMessage = "Pod is OK"
-		}
-		restartCount := 0
-		for _, status := range pod.Status.ContainerStatuses {
-			restartCount += int(status.RestartCount)
 		}
-
-		response = append(response, Response{
-			ID:           GenerateRandomString(10),
-			Name:         name,
-			ReadyActual:  readyContainers,
-			ReadyDesired: len(pod.Spec.Containers),
-			Phase:        string(pod.Status.Phase),
-			Status:       string(latestCondition.Type),
-			Age:          ElapsedTimeShort(age.Time),
-			Labels:       labels,
-			Node:         pod.Spec.NodeName,
-			Restarts:     restartCount,
-			Condition: Condition{
-				OK:      latestConditionOK,
-				Message: latestConditionMessage,
-			},
-		})
+		latestConditionOK, _ = strconv.ParseBool(string(latestCondition.Status))
+	}
+	latestConditionMessage := latestCondition.Message
+	if latestCondition.Message == "" && latestConditionOK {
+		latestConditionMessage = "Pod is OK"
+	}
+	restartCount := 0
+	for _, status := range pod.Status.ContainerStatuses {
+		restartCount += int(status.RestartCount)
 	}
 
-	return c.JSON(http.StatusOK, response)
+	return PodRow{
+		ID:           GenerateRandomString(10),
+		Name:         name,
+		ReadyActual:  readyContainers,
+		ReadyDesired: len(pod.Spec.Containers),
+		Phase:        string(pod.Status.Phase),
+		Status:       string(latestCondition.Type),
+		Age:          ElapsedTimeShort(age.Time),
+		Labels:       labels,
+		Node:         pod.Spec.NodeName,
+		Restarts:     restartCount,
+		Condition: RowCondition{
+			OK:      latestConditionOK,
+			Message: latestConditionMessage,
+		},
+	}
 }
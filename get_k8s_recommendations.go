@@ -0,0 +1,110 @@
+package main
+
+import (
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type GetK8sRecommendationsHandler struct {
+	ID   string
+	Name string
+	NS   string
+	Days string
+}
+
+func (h *GetK8sRecommendationsHandler) ServeHTTP(c echo.Context) error {
+	type Savings struct {
+		CPU    string `json:"cpu"`
+		Memory string `json:"memory"`
+	}
+	type Response struct {
+		Since           time.Time                 `json:"since"`
+		Recommendations []ContainerRecommendation `json:"recommendations"`
+		// Savings adds up what over-provisioned containers would free
+		Savings Savings `json:"savings"`
+	}
+
+	days := UsageSettings.RetentionDays
+	if h.Days != "" {
+		var err error
+		if days, err = strconv.Atoi(h.Days); err != nil || days <= 0 {
+			return c.String(http.StatusBadRequest, "invalid days: "+h.Days)
+		}
+	}
+	since := time.Now().Add(-time.Duration(days) * 24 * time.Hour)
+
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "GetK8sRecommendationsHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	usages, err := ContainerUsageStats(h.ID, h.Name, h.NS, since)
+	if err != nil {
+		logger.Warnf("Failed to get usage statistics when calling GetK8sRecommendationsHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	response := Response{
+		Since:           since,
+		Recommendations: make([]ContainerRecommendation, 0),
+	}
+
+	deployments, err := ListDeployments(clientset, h.ID, h.Name, h.NS)
+	if err != nil {
+		logger.Warnf("Failed to get deployments when calling GetK8sRecommendationsHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+	for _, deployment := range deployments {
+		var replicas int32 = 1
+		if deployment.Spec.Replicas != nil {
+			replicas = *deployment.Spec.Replicas
+		}
+		response.Recommendations = append(response.Recommendations, RecommendWorkload("Deployment", deployment.Name, replicas, deployment.Spec.Template.Spec, usages)...)
+	}
+
+	statefulSets, err := ListStatefulSets(clientset, h.ID, h.Name, h.NS)
+	if err != nil {
+		logger.Warnf("Failed to get stateful sets when calling GetK8sRecommendationsHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+	for _, statefulSet := range statefulSets {
+		var replicas int32 = 1
+		if statefulSet.Spec.Replicas != nil {
+			replicas = *statefulSet.Spec.Replicas
+		}
+		response.Recommendations = append(response.Recommendations, RecommendWorkload("StatefulSet", statefulSet.Name, replicas, statefulSet.Spec.Template.Spec, usages)...)
+	}
+
+	daemonSets, err := ListDaemonSets(clientset, h.ID, h.Name, h.NS)
+	if err != nil {
+		logger.Warnf("Failed to get daemon sets when calling GetK8sRecommendationsHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+	for _, daemonSet := range daemonSets {
+		response.Recommendations = append(response.Recommendations, RecommendWorkload("DaemonSet", daemonSet.Name, daemonSet.Status.DesiredNumberScheduled, daemonSet.Spec.Template.Spec, usages)...)
+	}
+
+	var cpuSavings, memorySavings int64
+	for _, recommendation := range response.Recommendations {
+		if recommendation.Status != RecommendationOverProvisioned {
+			continue
+		}
+		if recommendation.CPU.SavingsValue > 0 {
+			cpuSavings += recommendation.CPU.SavingsValue
+		}
+		if recommendation.Memory.SavingsValue > 0 {
+			memorySavings += recommendation.Memory.SavingsValue
+		}
+	}
+	response.Savings = Savings{
+		CPU:    usageQuantity(v1.ResourceCPU, cpuSavings).String(),
+		Memory: usageQuantity(v1.ResourceMemory, memorySavings).String(),
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
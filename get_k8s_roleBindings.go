@@ -0,0 +1,74 @@
+package main
+
+import (
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/rbac/v1"
+	"net/http"
+)
+
+type GetK8sRoleBindingsHandler struct {
+	ID   string
+	Name string
+	NS   string
+}
+
+type RoleBindingRow struct {
+	ID        string       `json:"id"`
+	Name      string       `json:"name"`
+	Role      string       `json:"role"`
+	Subjects  []string     `json:"subjects"`
+	Age       string       `json:"age"`
+	Labels    []string     `json:"labels"`
+	Condition RowCondition `json:"condition"`
+}
+
+func (h *GetK8sRoleBindingsHandler) ServeHTTP(c echo.Context) error {
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "GetK8sRoleBindingsHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	rbac, err := LoadRBAC(clientset, h.NS)
+	if err != nil {
+		logger.Warnf("Failed to get role bindings when calling GetK8sRoleBindingsHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	var response = make([]RoleBindingRow, 0)
+	for _, roleBinding := range rbac.RoleBindings {
+		_, found := rbac.RoleRules(roleBinding.Namespace, roleBinding.RoleRef)
+		response = append(response, NewRoleBindingRow(roleBinding, found))
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// NewRoleBindingRow marks bindings to missing roles, they grant nothing until the role is created
+func NewRoleBindingRow(roleBinding v1.RoleBinding, roleFound bool) RoleBindingRow {
+	name := roleBinding.GenerateName + roleBinding.Name
+
+	age := roleBinding.GetObjectMeta().GetCreationTimestamp()
+
+	row := RoleBindingRow{
+		ID:       GenerateRandomString(10),
+		Name:     name,
+		Role:     roleBinding.RoleRef.Kind + "/" + roleBinding.RoleRef.Name,
+		Subjects: FormatSubjects(roleBinding.Subjects, roleBinding.Namespace),
+		Age:      ElapsedTimeShort(age.Time),
+		Labels:   FormatKeyValues(roleBinding.GetLabels()),
+		Condition: RowCondition{
+			OK:      true,
+			Message: "Role Binding is OK",
+		},
+	}
+	if !roleFound {
+		row.Condition = RowCondition{
+			OK:      false,
+			Message: row.Role + " does not exist",
+		}
+	}
+
+	return row
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
+)
+
+type GetK8sRolesHandler struct {
+	ID   string
+	Name string
+	NS   string
+}
+
+type RoleRow struct {
+	ID        string       `json:"id"`
+	Name      string       `json:"name"`
+	Rules     []string     `json:"rules"`
+	Age       string       `json:"age"`
+	Labels    []string     `json:"labels"`
+	Condition RowCondition `json:"condition"`
+}
+
+func (h *GetK8sRolesHandler) ServeHTTP(c echo.Context) error {
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "GetK8sRolesHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	roles, err := clientset.RbacV1().Roles(h.NS).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		logger.Warnf("Failed to get roles when calling GetK8sRolesHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	var response = make([]RoleRow, 0)
+	for _, role := range roles.Items {
+		response = append(response, NewRoleRow(role))
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func NewRoleRow(role v1.Role) RoleRow {
+	name := role.GenerateName + role.Name
+
+	age := role.GetObjectMeta().GetCreationTimestamp()
+
+	return RoleRow{
+		ID:     GenerateRandomString(10),
+		Name:   name,
+		Rules:  FormatPolicyRules(role.Rules),
+		Age:    ElapsedTimeShort(age.Time),
+		Labels: FormatKeyValues(role.GetLabels()),
+		Condition: RowCondition{
+			OK:      true,
+			Message: "Role is OK",
+		},
+	}
+}
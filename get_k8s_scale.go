@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
+)
+
+// ScaleResponse describes the replicas of a scalable workload, Warning is set when an autoscaler manages them
+type ScaleResponse struct {
+	Kind    string `json:"kind"`
+	Current int32  `json:"current"`
+	Desired int32  `json:"desired"`
+	Warning string `json:"warning"`
+}
+
+type GetK8sScaleHandler struct {
+	ID     string
+	Name   string
+	NS     string
+	Kind   string
+	Object string
+}
+
+func (h *GetK8sScaleHandler) ServeHTTP(c echo.Context) error {
+	config, errMsg, err := GetRestConfig(h.ID, h.Name, "GetK8sScaleHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "GetK8sScaleHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	scaleClient, err := NewScaleClient(config, clientset)
+	if err != nil {
+		logger.Warnf("Failed to create scale client when calling GetK8sScaleHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+	resource, groupKind, err := scaleClient.Resource(h.Kind)
+	if err != nil {
+		logger.Warnf("Failed to resolve kind %s when calling GetK8sScaleHandler: %v", h.Kind, err)
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+
+	scale, err := scaleClient.Scales(h.NS).Get(context.Background(), resource, h.Object, metav1.GetOptions{})
+	if err != nil {
+		logger.Warnf("Failed to get scale when calling GetK8sScaleHandler: %v", err)
+		return c.String(http.StatusInternalServerError, err.Error())
+	}
+
+	hpa, err := FindHPA(clientset, h.NS, groupKind, h.Object)
+	if err != nil {
+		logger.Warnf("Failed to get horizontal pod autoscalers when calling GetK8sScaleHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	return c.JSON(http.StatusOK, ScaleResponse{
+		Kind:    groupKind.Kind,
+		Current: scale.Status.Replicas,
+		Desired: scale.Spec.Replicas,
+		Warning: HPAWarning(hpa),
+	})
+}
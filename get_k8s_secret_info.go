@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	v1core "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
+)
+
+type GetK8sSecretInfoHandler struct {
+	ID     string
+	Name   string
+	NS     string
+	Secret string
+}
+
+func (h *GetK8sSecretInfoHandler) ServeHTTP(c echo.Context) error {
+	type Summary struct {
+		Type      string   `json:"type"`
+		Keys      []string `json:"keys"`
+		Immutable bool     `json:"immutable"`
+		UsedBy    []string `json:"used_by"`
+	}
+	// There is no YAML on purpose, it would expose the secret values
+	type Response struct {
+		Summary  Summary          `json:"summary"`
+		Metadata ResourceMetadata `json:"metadata"`
+	}
+
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "GetK8sSecretInfoHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	secret, err := clientset.CoreV1().Secrets(h.NS).Get(context.Background(), h.Secret, v1.GetOptions{})
+	if err != nil {
+		logger.Warnf("Failed to get secret when calling GetK8sSecretInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	pods, err := ListPods(clientset, h.ID, h.Name, h.NS)
+	if err != nil {
+		logger.Warnf("Failed to get pods when calling GetK8sSecretInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	row := NewSecretRow(*secret)
+	summary := Summary{
+		Type:      row.Type,
+		Keys:      row.Keys,
+		Immutable: row.Immutable,
+		UsedBy:    PodsUsing(pods, "Secret", secret.Name),
+	}
+
+	// kubectl apply keeps the whole object, values included, in this annotation
+	annotations := make(map[string]string)
+	for key, value := range secret.Annotations {
+		if key != v1core.LastAppliedConfigAnnotation {
+			annotations[key] = value
+		}
+	}
+	secret.Annotations = annotations
+
+	response := Response{
+		Summary:  summary,
+		Metadata: NewResourceMetadata(secret),
+	}
+	return c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
+	"sort"
+)
+
+type GetK8sSecretsHandler struct {
+	ID   string
+	Name string
+	NS   string
+}
+
+// SecretRow never carries secret values, only the names of the keys
+type SecretRow struct {
+	ID        string       `json:"id"`
+	Name      string       `json:"name"`
+	Type      string       `json:"type"`
+	Keys      []string     `json:"keys"`
+	Immutable bool         `json:"immutable"`
+	Age       string       `json:"age"`
+	Labels    []string     `json:"labels"`
+	Condition RowCondition `json:"condition"`
+}
+
+func (h *GetK8sSecretsHandler) ServeHTTP(c echo.Context) error {
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "GetK8sSecretsHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	secrets, err := clientset.CoreV1().Secrets(h.NS).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		logger.Warnf("Failed to get secrets when calling GetK8sSecretsHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	var response = make([]SecretRow, 0)
+	for _, secret := range secrets.Items {
+		response = append(response, NewSecretRow(secret))
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func NewSecretRow(secret v1.Secret) SecretRow {
+	name := secret.GenerateName + secret.Name
+
+	age := secret.GetObjectMeta().GetCreationTimestamp()
+
+	keys := make([]string, 0)
+	for key := range secret.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return SecretRow{
+		ID:        GenerateRandomString(10),
+		Name:      name,
+		Type:      string(secret.Type),
+		Keys:      keys,
+		Immutable: secret.Immutable != nil && *secret.Immutable,
+		Age:       ElapsedTimeShort(age.Time),
+		Labels:    FormatKeyValues(secret.GetLabels()),
+		Condition: RowCondition{
+			OK:      true,
+			Message: "Secret is OK",
+		},
+	}
+}
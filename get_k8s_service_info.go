@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"net/http"
+)
+
+type GetK8sServiceInfoHandler struct {
+	ID      string
+	Name    string
+	NS      string
+	Service string
+}
+
+func (h *GetK8sServiceInfoHandler) ServeHTTP(c echo.Context) error {
+	type Port struct {
+		Name       string `json:"name"`
+		Port       int32  `json:"port"`
+		TargetPort string `json:"target_port"`
+		NodePort   int32  `json:"node_port"`
+		Protocol   string `json:"protocol"`
+	}
+	type Endpoint struct {
+		Addresses []string `json:"addresses"`
+		Ready     bool     `json:"ready"`
+		Target    string   `json:"target"`
+		Node      string   `json:"node"`
+		Zone      string   `json:"zone"`
+	}
+	type Summary struct {
+		Type            string                `json:"type"`
+		ClusterIPs      []string              `json:"cluster_ips"`
+		ExternalIPs     []string              `json:"external_ips"`
+		SessionAffinity string                `json:"session_affinity"`
+		Ports           []Port                `json:"ports"`
+		Selectors       []string              `json:"selectors"`
+		EndpointsCount  ServiceEndpointsCount `json:"endpoints_count"`
+		Endpoints       []Endpoint            `json:"endpoints"`
+		Pods            []string              `json:"pods"`
+	}
+	type Response struct {
+		Summary  Summary          `json:"summary"`
+		Metadata ResourceMetadata `json:"metadata"`
+		Graph    *Graph           `json:"graph"`
+		YAML     string           `json:"yaml"`
+	}
+
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "GetK8sServiceInfoHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	service, err := clientset.CoreV1().Services(h.NS).Get(context.Background(), h.Service, v1.GetOptions{})
+	if err != nil {
+		logger.Warnf("Failed to get service when calling GetK8sServiceInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	endpointSlices, err := clientset.DiscoveryV1().EndpointSlices(h.NS).List(context.Background(), v1.ListOptions{
+		LabelSelector: discoveryv1.LabelServiceName + "=" + service.Name,
+	})
+	if err != nil {
+		logger.Warnf("Failed to get endpoint slices when calling GetK8sServiceInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	row := NewServiceRow(*service, endpointSlices.Items)
+	summary := Summary{
+		Type:            row.Type,
+		ClusterIPs:      service.Spec.ClusterIPs,
+		ExternalIPs:     row.ExternalIPs,
+		SessionAffinity: string(service.Spec.SessionAffinity),
+		Ports:           make([]Port, 0),
+		Selectors:       row.Selectors,
+		EndpointsCount:  row.Endpoints,
+		Endpoints:       make([]Endpoint, 0),
+		Pods:            make([]string, 0),
+	}
+
+	for _, port := range service.Spec.Ports {
+		summary.Ports = append(summary.Ports, Port{
+			Name:       port.Name,
+			Port:       port.Port,
+			TargetPort: port.TargetPort.String(),
+			NodePort:   port.NodePort,
+			Protocol:   string(port.Protocol),
+		})
+	}
+
+	for _, slice := range endpointSlices.Items {
+		for _, endpoint := range slice.Endpoints {
+			e := Endpoint{
+				Addresses: endpoint.Addresses,
+				Ready:     endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready,
+			}
+			if endpoint.TargetRef != nil {
+				e.Target = endpoint.TargetRef.Kind + "/" + endpoint.TargetRef.Name
+			}
+			if endpoint.NodeName != nil {
+				e.Node = *endpoint.NodeName
+			}
+			if endpoint.Zone != nil {
+				e.Zone = *endpoint.Zone
+			}
+			summary.Endpoints = append(summary.Endpoints, e)
+		}
+	}
+
+	if len(service.Spec.Selector) > 0 {
+		pods, err := clientset.CoreV1().Pods(h.NS).List(context.Background(), v1.ListOptions{
+			LabelSelector: labels.SelectorFromSet(service.Spec.Selector).String(),
+		})
+		if err != nil {
+			logger.Warnf("Failed to get pods when calling GetK8sServiceInfoHandler: %v", err)
+			return c.NoContent(http.StatusInternalServerError)
+		}
+		for _, pod := range pods.Items {
+			summary.Pods = append(summary.Pods, pod.Name)
+		}
+	}
+
+	traverser := DAGTraverser{
+		Visited: make(map[string]bool),
+		Graph:   new(DAGTraverser).CreateGraph(),
+	}
+	if err := traverser.GenerateDAGForResource(clientset, KubernetesResource{ResourceName: h.Service, ResourceType: "Service", ResourceNamespace: h.NS}); err != nil {
+		logger.Warnf("Failed to get K8S resource traverse when calling GetK8sServiceInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	yamlStr, err := ResourceYAML(service, "v1", "Service")
+	if err != nil {
+		logger.Warnf("Error marshaling to YAML when calling GetK8sServiceInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	response := Response{
+		Summary:  summary,
+		Metadata: NewResourceMetadata(service),
+		Graph:    traverser.Graph,
+		YAML:     yamlStr,
+	}
+	return c.JSON(http.StatusOK, response)
+}
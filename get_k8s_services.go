@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
+	"strings"
+)
+
+type GetK8sServicesHandler struct {
+	ID   string
+	Name string
+	NS   string
+}
+
+type ServiceEndpointsCount struct {
+	Ready    int `json:"ready"`
+	NotReady int `json:"not_ready"`
+}
+
+type ServiceRow struct {
+	ID          string                `json:"id"`
+	Name        string                `json:"name"`
+	Type        string                `json:"type"`
+	ClusterIP   string                `json:"cluster_ip"`
+	ExternalIPs []string              `json:"external_ips"`
+	Ports       []string              `json:"ports"`
+	Selectors   []string              `json:"selectors"`
+	Endpoints   ServiceEndpointsCount `json:"endpoints"`
+	Age         string                `json:"age"`
+	Labels      []string              `json:"labels"`
+	Condition   RowCondition          `json:"condition"`
+}
+
+func (h *GetK8sServicesHandler) ServeHTTP(c echo.Context) error {
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "GetK8sServicesHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	services, err := clientset.CoreV1().Services(h.NS).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		logger.Warnf("Failed to get services when calling GetK8sServicesHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	endpointSlices, err := clientset.DiscoveryV1().EndpointSlices(h.NS).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		logger.Warnf("Failed to get endpoint slices when calling GetK8sServicesHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+	serviceEndpointSlices := make(map[string][]discoveryv1.EndpointSlice)
+	for _, slice := range endpointSlices.Items {
+		key := slice.Namespace + "/" + slice.Labels[discoveryv1.LabelServiceName]
+		serviceEndpointSlices[key] = append(serviceEndpointSlices[key], slice)
+	}
+
+	var response = make([]ServiceRow, 0)
+	for _, service := range services.Items {
+		response = append(response, NewServiceRow(service, serviceEndpointSlices[service.Namespace+"/"+service.Name]))
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func NewServiceRow(service v1.Service, endpointSlices []discoveryv1.EndpointSlice) ServiceRow {
+	name := service.GenerateName + service.Name
+
+	age := service.GetObjectMeta().GetCreationTimestamp()
+
+	externalIPs := make([]string, 0)
+	externalIPs = append(externalIPs, service.Spec.ExternalIPs...)
+	for _, ingress := range service.Status.LoadBalancer.Ingress {
+		if ingress.IP != "" {
+			externalIPs = append(externalIPs, ingress.IP)
+		}
+		if ingress.Hostname != "" {
+			externalIPs = append(externalIPs, ingress.Hostname)
+		}
+	}
+
+	ports := make([]string, 0)
+	for _, port := range service.Spec.Ports {
+		ports = append(ports, FormatServicePort(port))
+	}
+
+	ready, notReady := CountEndpoints(endpointSlices)
+
+	conditionOK := true
+	conditionMessage := "Service is OK"
+	if service.Spec.Type == v1.ServiceTypeLoadBalancer && len(service.Status.LoadBalancer.Ingress) == 0 {
+		conditionOK = false
+		conditionMessage = "Load balancer is not provisioned yet"
+	} else if len(service.Spec.Selector) > 0 && ready == 0 {
+		conditionOK = false
+		conditionMessage = "Service has no ready endpoints"
+	}
+
+	return ServiceRow{
+		ID:          GenerateRandomString(10),
+		Name:        name,
+		Type:        string(service.Spec.Type),
+		ClusterIP:   service.Spec.ClusterIP,
+		ExternalIPs: externalIPs,
+		Ports:       ports,
+		Selectors:   FormatKeyValues(service.Spec.Selector),
+		Endpoints: ServiceEndpointsCount{
+			Ready:    ready,
+			NotReady: notReady,
+		},
+		Age:    ElapsedTimeShort(age.Time),
+		Labels: FormatKeyValues(service.GetLabels()),
+		Condition: RowCondition{
+			OK:      conditionOK,
+			Message: conditionMessage,
+		},
+	}
+}
+
+// FormatServicePort formats a port like kubectl does, e.g. "80:30080/TCP"
+func FormatServicePort(port v1.ServicePort) string {
+	var formatted strings.Builder
+	formatted.WriteString(fmt.Sprintf("%d", port.Port))
+	if port.NodePort != 0 {
+		formatted.WriteString(fmt.Sprintf(":%d", port.NodePort))
+	}
+	formatted.WriteString("/" + string(port.Protocol))
+	return formatted.String()
+}
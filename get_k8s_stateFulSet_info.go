@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type GetK8sStateFulSetInfoHandler struct {
+	ID          string
+	Name        string
+	NS          string
+	StateFulSet string
+}
+
+func (h *GetK8sStateFulSetInfoHandler) ServeHTTP(c echo.Context) error {
+	type Configuration struct {
+		Replicas            int32  `json:"replicas"`
+		ServiceName         string `json:"service_name"`
+		PodManagementPolicy string `json:"pod_management_policy"`
+		UpdateStrategy      string `json:"update_strategy"`
+		Partition           int32  `json:"partition"`
+	}
+	type Status struct {
+		Replicas          int32  `json:"replicas"`
+		ReadyReplicas     int32  `json:"ready_replicas"`
+		CurrentReplicas   int32  `json:"current_replicas"`
+		UpdatedReplicas   int32  `json:"updated_replicas"`
+		AvailableReplicas int32  `json:"available_replicas"`
+		CurrentRevision   string `json:"current_revision"`
+		UpdateRevision    string `json:"update_revision"`
+	}
+	type OrdinalPod struct {
+		SummaryPod
+		Ordinal int `json:"ordinal"`
+		// Pods below the partition keep the current revision during a rolling update
+		Updated bool `json:"updated"`
+	}
+	type VolumeClaimTemplate struct {
+		Name         string   `json:"name"`
+		StorageClass string   `json:"storage_class"`
+		AccessModes  []string `json:"access_modes"`
+		Storage      string   `json:"storage"`
+	}
+	type Summary struct {
+		Configuration        Configuration         `json:"configuration"`
+		Status               Status                `json:"status"`
+		PodSelectors         []string              `json:"pod_selectors"`
+		Pods                 []OrdinalPod          `json:"pods"`
+		VolumeClaimTemplates []VolumeClaimTemplate `json:"volume_claim_templates"`
+		Template             []SummaryContainer    `json:"template"`
+		Volumes              []SummaryVolume       `json:"volumes"`
+		Conditions           []SummaryCondition    `json:"conditions"`
+		Events               []ObjectEvent         `json:"events"`
+	}
+	type Response struct {
+		Summary  Summary          `json:"summary"`
+		Metadata ResourceMetadata `json:"metadata"`
+		YAML     string           `json:"yaml"`
+	}
+
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "GetK8sStateFulSetInfoHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	statefulSet, err := clientset.AppsV1().StatefulSets(h.NS).Get(context.Background(), h.StateFulSet, v1.GetOptions{})
+	if err != nil {
+		logger.Warnf("Failed to get stateful set when calling GetK8sStateFulSetInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	yamlStr, err := ResourceYAML(statefulSet, "apps/v1", "StatefulSet")
+	if err != nil {
+		logger.Warnf("Error marshaling to YAML when calling GetK8sStateFulSetInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	var replicas int32 = 1
+	if statefulSet.Spec.Replicas != nil {
+		replicas = *statefulSet.Spec.Replicas
+	}
+	var partition int32
+	if rollingUpdate := statefulSet.Spec.UpdateStrategy.RollingUpdate; rollingUpdate != nil && rollingUpdate.Partition != nil {
+		partition = *rollingUpdate.Partition
+	}
+
+	summary := Summary{
+		Configuration: Configuration{
+			Replicas:            replicas,
+			ServiceName:         statefulSet.Spec.ServiceName,
+			PodManagementPolicy: string(statefulSet.Spec.PodManagementPolicy),
+			UpdateStrategy:      string(statefulSet.Spec.UpdateStrategy.Type),
+			Partition:           partition,
+		},
+		Status: Status{
+			Replicas:          statefulSet.Status.Replicas,
+			ReadyReplicas:     statefulSet.Status.ReadyReplicas,
+			CurrentReplicas:   statefulSet.Status.CurrentReplicas,
+			UpdatedReplicas:   statefulSet.Status.UpdatedReplicas,
+			AvailableReplicas: statefulSet.Status.AvailableReplicas,
+			CurrentRevision:   statefulSet.Status.CurrentRevision,
+			UpdateRevision:    statefulSet.Status.UpdateRevision,
+		},
+		PodSelectors:         FormatKeyValues(statefulSet.Spec.Selector.MatchLabels),
+		Pods:                 make([]OrdinalPod, 0),
+		VolumeClaimTemplates: make([]VolumeClaimTemplate, 0),
+		Template:             NewSummaryContainers(statefulSet.Spec.Template.Spec.Containers),
+		Volumes:              NewSummaryVolumes(statefulSet.Spec.Template.Spec.Volumes),
+		Conditions:           make([]SummaryCondition, 0),
+	}
+
+	pods, err := ListSelectedPods(clientset, h.NS, statefulSet.Spec.Selector)
+	if err != nil {
+		logger.Warnf("Failed to get pods when calling GetK8sStateFulSetInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+	for _, pod := range ControlledPods(pods, statefulSet) {
+		ordinal, err := strconv.Atoi(strings.TrimPrefix(pod.Name, statefulSet.Name+"-"))
+		if err != nil {
+			ordinal = -1
+		}
+		summary.Pods = append(summary.Pods, OrdinalPod{
+			SummaryPod: NewSummaryPod(pod),
+			Ordinal:    ordinal,
+			Updated:    pod.Labels["controller-revision-hash"] == statefulSet.Status.UpdateRevision,
+		})
+	}
+	sort.SliceStable(summary.Pods, func(i, j int) bool {
+		return summary.Pods[i].Ordinal < summary.Pods[j].Ordinal
+	})
+
+	for _, template := range statefulSet.Spec.VolumeClaimTemplates {
+		claimTemplate := VolumeClaimTemplate{
+			Name:        template.Name,
+			AccessModes: FormatAccessModes(template.Spec.AccessModes),
+		}
+		if template.Spec.StorageClassName != nil {
+			claimTemplate.StorageClass = *template.Spec.StorageClassName
+		}
+		if storage, ok := template.Spec.Resources.Requests["storage"]; ok {
+			claimTemplate.Storage = storage.String()
+		}
+		summary.VolumeClaimTemplates = append(summary.VolumeClaimTemplates, claimTemplate)
+	}
+
+	for _, condition := range statefulSet.Status.Conditions {
+		summary.Conditions = append(summary.Conditions, NewSummaryCondition(string(condition.Type), string(condition.Status),
+			condition.Reason, condition.Message, time.Time{}, condition.LastTransitionTime.Time))
+	}
+
+	summary.Events, err = CollectObjectEvents(clientset, h.NS, "StatefulSet", h.StateFulSet)
+	if err != nil {
+		logger.Warnf("Failed to get events when calling GetK8sStateFulSetInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	response := Response{
+		Summary:  summary,
+		Metadata: NewResourceMetadata(statefulSet),
+		YAML:     yamlStr,
+	}
+	return c.JSON(http.StatusOK, response)
+}
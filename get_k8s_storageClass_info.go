@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	v1core "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
+	"sort"
+)
+
+type GetK8sStorageClassInfoHandler struct {
+	ID           string
+	Name         string
+	StorageClass string
+}
+
+func (h *GetK8sStorageClassInfoHandler) ServeHTTP(c echo.Context) error {
+	type Summary struct {
+		Provisioner            string   `json:"provisioner"`
+		ReclaimPolicy          string   `json:"reclaim_policy"`
+		VolumeBindingMode      string   `json:"volume_binding_mode"`
+		AllowVolumeExpansion   bool     `json:"allow_volume_expansion"`
+		Default                bool     `json:"default"`
+		Parameters             []string `json:"parameters"`
+		MountOptions           []string `json:"mount_options"`
+		PersistentVolumeClaims []string `json:"persistent_volume_claims"`
+		UsedBy                 []string `json:"used_by"`
+	}
+	type Response struct {
+		Summary  Summary          `json:"summary"`
+		Metadata ResourceMetadata `json:"metadata"`
+		YAML     string           `json:"yaml"`
+	}
+
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "GetK8sStorageClassInfoHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	storageClass, err := clientset.StorageV1().StorageClasses().Get(context.Background(), h.StorageClass, v1.GetOptions{})
+	if err != nil {
+		logger.Warnf("Failed to get storage class when calling GetK8sStorageClassInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	row := NewStorageClassRow(*storageClass)
+	summary := Summary{
+		Provisioner:            row.Provisioner,
+		ReclaimPolicy:          row.ReclaimPolicy,
+		VolumeBindingMode:      row.VolumeBindingMode,
+		AllowVolumeExpansion:   row.AllowVolumeExpansion,
+		Default:                row.Default,
+		Parameters:             FormatKeyValues(storageClass.Parameters),
+		MountOptions:           storageClass.MountOptions,
+		PersistentVolumeClaims: make([]string, 0),
+		UsedBy:                 make([]string, 0),
+	}
+
+	// A storage class is used by the pods that mount claims provisioned from it
+	pvcs, err := clientset.CoreV1().PersistentVolumeClaims(v1.NamespaceAll).List(context.Background(), v1.ListOptions{})
+	if err != nil {
+		logger.Warnf("Failed to get persistent volume claims when calling GetK8sStorageClassInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+	claimsByNamespace := make(map[string][]v1core.PersistentVolumeClaim)
+	for _, pvc := range pvcs.Items {
+		if pvc.Spec.StorageClassName != nil && *pvc.Spec.StorageClassName == storageClass.Name {
+			summary.PersistentVolumeClaims = append(summary.PersistentVolumeClaims, pvc.Namespace+"/"+pvc.Name)
+			claimsByNamespace[pvc.Namespace] = append(claimsByNamespace[pvc.Namespace], pvc)
+		}
+	}
+	for namespace, claims := range claimsByNamespace {
+		pods, err := ListPods(clientset, h.ID, h.Name, namespace)
+		if err != nil {
+			logger.Warnf("Failed to get pods when calling GetK8sStorageClassInfoHandler: %v", err)
+			return c.NoContent(http.StatusInternalServerError)
+		}
+		usedBy := make(map[string]bool)
+		for _, pvc := range claims {
+			for _, pod := range PodsUsing(pods, "PersistentVolumeClaim", pvc.Name) {
+				usedBy[namespace+"/"+pod] = true
+			}
+		}
+		for pod := range usedBy {
+			summary.UsedBy = append(summary.UsedBy, pod)
+		}
+	}
+	sort.Strings(summary.UsedBy)
+
+	yamlStr, err := ResourceYAML(storageClass, "storage.k8s.io/v1", "StorageClass")
+	if err != nil {
+		logger.Warnf("Error marshaling to YAML when calling GetK8sStorageClassInfoHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	response := Response{
+		Summary:  summary,
+		Metadata: NewResourceMetadata(storageClass),
+		YAML:     yamlStr,
+	}
+	return c.JSON(http.StatusOK, response)
+}
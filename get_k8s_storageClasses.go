@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
+)
+
+const DefaultStorageClassAnnotation = "storageclass.kubernetes.io/is-default-class"
+
+type GetK8sStorageClassesHandler struct {
+	ID   string
+	Name string
+}
+
+type StorageClassRow struct {
+	ID                   string       `json:"id"`
+	Name                 string       `json:"name"`
+	Provisioner          string       `json:"provisioner"`
+	ReclaimPolicy        string       `json:"reclaim_policy"`
+	VolumeBindingMode    string       `json:"volume_binding_mode"`
+	AllowVolumeExpansion bool         `json:"allow_volume_expansion"`
+	Default              bool         `json:"default"`
+	Age                  string       `json:"age"`
+	Labels               []string     `json:"labels"`
+	Condition            RowCondition `json:"condition"`
+}
+
+func (h *GetK8sStorageClassesHandler) ServeHTTP(c echo.Context) error {
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "GetK8sStorageClassesHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	storageClasses, err := clientset.StorageV1().StorageClasses().List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		logger.Warnf("Failed to get storage classes when calling GetK8sStorageClassesHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	var response = make([]StorageClassRow, 0)
+	for _, storageClass := range storageClasses.Items {
+		response = append(response, NewStorageClassRow(storageClass))
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func NewStorageClassRow(storageClass v1.StorageClass) StorageClassRow {
+	name := storageClass.GenerateName + storageClass.Name
+
+	age := storageClass.GetObjectMeta().GetCreationTimestamp()
+
+	row := StorageClassRow{
+		ID:                   GenerateRandomString(10),
+		Name:                 name,
+		Provisioner:          storageClass.Provisioner,
+		AllowVolumeExpansion: storageClass.AllowVolumeExpansion != nil && *storageClass.AllowVolumeExpansion,
+		Default:              storageClass.Annotations[DefaultStorageClassAnnotation] == "true",
+		Age:                  ElapsedTimeShort(age.Time),
+		Labels:               FormatKeyValues(storageClass.GetLabels()),
+		Condition: RowCondition{
+			OK:      true,
+			Message: "Storage Class is OK",
+		},
+	}
+	if storageClass.ReclaimPolicy != nil {
+		row.ReclaimPolicy = string(*storageClass.ReclaimPolicy)
+	}
+	if storageClass.VolumeBindingMode != nil {
+		row.VolumeBindingMode = string(*storageClass.VolumeBindingMode)
+	}
+
+	return row
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"net/http"
+	"time"
+)
+
+type GetK8sUsageSeriesHandler struct {
+	ID     string
+	Name   string
+	NS     string
+	Kind   string
+	Object string
+	From   string
+	To     string
+}
+
+type UsagePoint struct {
+	Time time.Time `json:"time" bson:"_id"`
+	// CPU in millicores and memory in bytes, averaged over the step and summed over the pods of a workload
+	CPU    int64 `json:"cpu" bson:"cpu"`
+	Memory int64 `json:"memory" bson:"memory"`
+	Pods   int   `json:"pods" bson:"pods"`
+}
+
+// usageWorkloadKinds are aggregated over their pods, which are sampled with their owner
+var usageWorkloadKinds = map[string]bool{
+	"Deployment":            true,
+	"StatefulSet":           true,
+	"DaemonSet":             true,
+	"Job":                   true,
+	"ReplicaSet":            true,
+	"ReplicationController": true,
+}
+
+func (h *GetK8sUsageSeriesHandler) ServeHTTP(c echo.Context) error {
+	type Response struct {
+		From        time.Time    `json:"from"`
+		To          time.Time    `json:"to"`
+		StepSeconds int64        `json:"step_seconds"`
+		Points      []UsagePoint `json:"points"`
+	}
+
+	to := time.Now()
+	from := to.Add(-time.Hour)
+	var err error
+	if h.To != "" {
+		if to, err = time.Parse(time.RFC3339, h.To); err != nil {
+			return c.String(http.StatusBadRequest, "invalid to: "+err.Error())
+		}
+	}
+	if h.From != "" {
+		if from, err = time.Parse(time.RFC3339, h.From); err != nil {
+			return c.String(http.StatusBadRequest, "invalid from: "+err.Error())
+		}
+	}
+	if !from.Before(to) {
+		return c.String(http.StatusBadRequest, "from must be before to")
+	}
+
+	filter := bson.M{
+		"meta.cluster_id": h.ID,
+		"meta.cluster":    h.Name,
+		"time":            bson.M{"$gte": from, "$lt": to},
+	}
+	switch {
+	case h.Kind == "Node":
+		filter["meta.kind"] = "Node"
+		filter["meta.name"] = h.Object
+	case h.Kind == "Pod":
+		filter["meta.kind"] = "Pod"
+		filter["meta.namespace"] = h.NS
+		filter["meta.name"] = h.Object
+	case usageWorkloadKinds[h.Kind]:
+		filter["meta.kind"] = "Pod"
+		filter["meta.namespace"] = h.NS
+		filter["meta.owner_kind"] = h.Kind
+		filter["meta.owner_name"] = h.Object
+	default:
+		return c.String(http.StatusBadRequest, "usage is not recorded for "+h.Kind)
+	}
+
+	// Downsample so that the range fits in the configured number of points
+	step := time.Duration(UsageSettings.IntervalSeconds) * time.Second
+	if rangeStep := to.Sub(from) / time.Duration(UsageSettings.MaxPoints); rangeStep > step {
+		step = rangeStep.Truncate(time.Second) + time.Second
+	}
+	stepSeconds := int64(step.Seconds())
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		// Average the samples of each pod within a step first, then sum the pods of a workload
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{
+				"time": bson.M{"$dateTrunc": bson.M{"date": "$time", "unit": "second", "binSize": stepSeconds}},
+				"name": "$meta.name",
+			},
+			"cpu":    bson.M{"$avg": "$cpu"},
+			"memory": bson.M{"$avg": "$memory"},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":    "$_id.time",
+			"cpu":    bson.M{"$sum": bson.M{"$toLong": "$cpu"}},
+			"memory": bson.M{"$sum": bson.M{"$toLong": "$memory"}},
+			"pods":   bson.M{"$sum": 1},
+		}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	}
+
+	points := make([]UsagePoint, 0)
+	if err := DBHelper.Aggregate(UsageSamples, pipeline, &points); err != nil {
+		logger.Warnf("Failed to get usage series when calling GetK8sUsageSeriesHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	return c.JSON(http.StatusOK, Response{
+		From:        from,
+		To:          to,
+		StepSeconds: stepSeconds,
+		Points:      points,
+	})
+}
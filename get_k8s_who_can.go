@@ -0,0 +1,79 @@
+package main
+
+import (
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
+	"strings"
+)
+
+// GetK8sWhoCanHandler answers "who can <verb> <resource> in <namespace>", cluster-wide when NS is empty
+type GetK8sWhoCanHandler struct {
+	ID       string
+	Name     string
+	NS       string
+	Verb     string
+	Group    string
+	Resource string
+}
+
+func (h *GetK8sWhoCanHandler) ServeHTTP(c echo.Context) error {
+	type Response struct {
+		Verb     string        `json:"verb"`
+		Group    string        `json:"group"`
+		Resource string        `json:"resource"`
+		Subjects []RBACSubject `json:"subjects"`
+		Grants   []RBACGrant   `json:"grants"`
+	}
+
+	if h.Verb == "" || h.Resource == "" {
+		logger.Warnf("Verb and resource are required when calling GetK8sWhoCanHandler")
+		return c.NoContent(http.StatusBadRequest)
+	}
+	// Accept resources in kubectl form like deployments.apps or deployments.apps/scale
+	resource, group := h.Resource, h.Group
+	if group == "" {
+		name, subresource, _ := strings.Cut(resource, "/")
+		if name, g, ok := strings.Cut(name, "."); ok {
+			group = g
+			resource = name
+			if subresource != "" {
+				resource += "/" + subresource
+			}
+		}
+	}
+
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "GetK8sWhoCanHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	ns := h.NS
+	if ns == "" {
+		ns = metav1.NamespaceAll
+	}
+	rbac, err := LoadRBAC(clientset, ns)
+	if err != nil {
+		logger.Warnf("Failed to load RBAC objects when calling GetK8sWhoCanHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	response := Response{
+		Verb:     h.Verb,
+		Group:    group,
+		Resource: resource,
+		Subjects: make([]RBACSubject, 0),
+		Grants:   rbac.WhoCan(h.Verb, group, resource, h.NS),
+	}
+	seen := make(map[RBACSubject]bool)
+	for _, grant := range response.Grants {
+		if !seen[grant.Subject] {
+			seen[grant.Subject] = true
+			response.Subjects = append(response.Subjects, grant.Subject)
+		}
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
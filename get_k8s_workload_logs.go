@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	v1core "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
+)
+
+// GetK8sWorkloadLogsHandler tails every pod of a Deployment, StatefulSet or Job.
+// Pods created after a follow stream has started are not picked up.
+type GetK8sWorkloadLogsHandler struct {
+	ID       string
+	Name     string
+	NS       string
+	Kind     string
+	Workload string
+	Query    LogQuery
+}
+
+func (h *GetK8sWorkloadLogsHandler) ServeHTTP(c echo.Context) error {
+	options, err := h.Query.Options()
+	if err != nil {
+		logger.Warnf("Invalid log options when calling GetK8sWorkloadLogsHandler: %v", err)
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+	if options.TailLines == nil && options.SinceSeconds == 0 {
+		tailLines := int64(DefaultAggregatedTailLines)
+		options.TailLines = &tailLines
+	}
+
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "GetK8sWorkloadLogsHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	var selector *v1.LabelSelector
+	switch h.Kind {
+	case "Deployment":
+		deployment, err := clientset.AppsV1().Deployments(h.NS).Get(context.Background(), h.Workload, v1.GetOptions{})
+		if err != nil {
+			logger.Warnf("Failed to get deployment when calling GetK8sWorkloadLogsHandler: %v", err)
+			return c.NoContent(http.StatusInternalServerError)
+		}
+		selector = deployment.Spec.Selector
+	case "StatefulSet":
+		statefulSet, err := clientset.AppsV1().StatefulSets(h.NS).Get(context.Background(), h.Workload, v1.GetOptions{})
+		if err != nil {
+			logger.Warnf("Failed to get stateful set when calling GetK8sWorkloadLogsHandler: %v", err)
+			return c.NoContent(http.StatusInternalServerError)
+		}
+		selector = statefulSet.Spec.Selector
+	case "Job":
+		job, err := clientset.BatchV1().Jobs(h.NS).Get(context.Background(), h.Workload, v1.GetOptions{})
+		if err != nil {
+			logger.Warnf("Failed to get job when calling GetK8sWorkloadLogsHandler: %v", err)
+			return c.NoContent(http.StatusInternalServerError)
+		}
+		selector = job.Spec.Selector
+	default:
+		return c.String(http.StatusBadRequest, "logs can be aggregated for Deployment, StatefulSet or Job")
+	}
+
+	pods, err := ListSelectedPods(clientset, h.NS, selector)
+	if err != nil {
+		logger.Warnf("Failed to get pods when calling GetK8sWorkloadLogsHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	targets := make([]LogTarget, 0)
+	for _, pod := range pods {
+		// Pending pods have no logs yet
+		if pod.Status.Phase == v1core.PodPending {
+			continue
+		}
+		container := options.Container
+		if container == "" {
+			container = DefaultContainer(pod)
+		}
+		targets = append(targets, LogTarget{Pod: pod.Name, Container: container})
+	}
+
+	if options.Follow {
+		return FollowLogs(c, clientset, h.NS, targets, options, true)
+	}
+
+	lines, err := CollectLogs(c.Request().Context(), clientset, h.NS, targets, options, true)
+	if err != nil {
+		logger.Warnf("Failed to get logs when calling GetK8sWorkloadLogsHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	return c.JSON(http.StatusOK, lines)
+}
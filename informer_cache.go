@@ -0,0 +1,253 @@
+package main
+
+import (
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+
+	logger "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+type ClusterInformer struct {
+	ID         string
+	Name       string
+	Factory    informers.SharedInformerFactory
+	Informers  map[string]cache.SharedIndexInformer
+	Started    time.Time
+	LastAccess time.Time
+	stopCh     chan struct{}
+}
+
+type InformerCache struct {
+	mu          sync.Mutex
+	enabled     bool
+	idleTimeout time.Duration
+	resync      time.Duration
+	clusters    map[string]*ClusterInformer
+}
+
+type InformerCacheKindStatus struct {
+	Kind    string `json:"kind"`
+	Objects int    `json:"objects"`
+	Bytes   int    `json:"bytes"`
+	Synced  bool   `json:"synced"`
+}
+
+type InformerCacheClusterStatus struct {
+	ID         string                    `json:"id"`
+	Name       string                    `json:"name"`
+	Synced     bool                      `json:"synced"`
+	Started    string                    `json:"started"`
+	LastAccess string                    `json:"last_access"`
+	Objects    int                       `json:"objects"`
+	Bytes      int                       `json:"bytes"`
+	Kinds      []InformerCacheKindStatus `json:"kinds"`
+}
+
+type InformerCacheStatus struct {
+	Enabled            bool                         `json:"enabled"`
+	IdleTimeoutMinutes int                          `json:"idle_timeout_minutes"`
+	HeapAllocBytes     uint64                       `json:"heap_alloc_bytes"`
+	Clusters           []InformerCacheClusterStatus `json:"clusters"`
+}
+
+func NewInformerCache(config CacheConfig) *InformerCache {
+	ic := &InformerCache{
+		enabled:     config.Enabled,
+		idleTimeout: time.Duration(config.IdleTimeoutMinutes) * time.Minute,
+		resync:      time.Duration(config.ResyncMinutes) * time.Minute,
+		clusters:    make(map[string]*ClusterInformer),
+	}
+	if ic.enabled {
+		go ic.stopIdleClusters()
+	}
+	return ic
+}
+
+// Cluster returns the informers of a cluster and starts them on first access.
+// It returns nil when the cache is disabled, callers should then go straight to the API server.
+func (ic *InformerCache) Cluster(id, name string, clientset kubernetes.Interface) *ClusterInformer {
+	if ic == nil || !ic.enabled {
+		return nil
+	}
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+
+	key := id + "_" + name
+	if ci, ok := ic.clusters[key]; ok {
+		ci.LastAccess = time.Now()
+		return ci
+	}
+
+	factory := informers.NewSharedInformerFactory(clientset, ic.resync)
+	ci := &ClusterInformer{
+		ID:      id,
+		Name:    name,
+		Factory: factory,
+		Informers: map[string]cache.SharedIndexInformer{
+			"Deployment":            factory.Apps().V1().Deployments().Informer(),
+			"ReplicaSet":            factory.Apps().V1().ReplicaSets().Informer(),
+			"StatefulSet":           factory.Apps().V1().StatefulSets().Informer(),
+			"DaemonSet":             factory.Apps().V1().DaemonSets().Informer(),
+			"Job":                   factory.Batch().V1().Jobs().Informer(),
+			"CronJob":               factory.Batch().V1().CronJobs().Informer(),
+			"Pod":                   factory.Core().V1().Pods().Informer(),
+			"ReplicationController": factory.Core().V1().ReplicationControllers().Informer(),
+		},
+		Started:    time.Now(),
+		LastAccess: time.Now(),
+		stopCh:     make(chan struct{}),
+	}
+	factory.Start(ci.stopCh)
+	ic.clusters[key] = ci
+	logger.Infof("Started informer cache for cluster %s of kubeconfig %s", name, id)
+
+	return ci
+}
+
+// Forget stops the informers of every cluster that belongs to the kubeconfig.
+func (ic *InformerCache) Forget(id string) {
+	if ic == nil {
+		return
+	}
+	ic.mu.Lock()
+	var stopped []*ClusterInformer
+	for key, ci := range ic.clusters {
+		if ci.ID == id {
+			stopped = append(stopped, ci)
+			delete(ic.clusters, key)
+		}
+	}
+	ic.mu.Unlock()
+
+	for _, ci := range stopped {
+		ci.stop()
+	}
+}
+
+func (ic *InformerCache) stopIdleClusters() {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+	for range ticker.C {
+		ic.mu.Lock()
+		var stopped []*ClusterInformer
+		for key, ci := range ic.clusters {
+			if time.Since(ci.LastAccess) > ic.idleTimeout {
+				stopped = append(stopped, ci)
+				delete(ic.clusters, key)
+			}
+		}
+		ic.mu.Unlock()
+
+		for _, ci := range stopped {
+			ci.stop()
+			logger.Infof("Stopped idle informer cache for cluster %s of kubeconfig %s", ci.Name, ci.ID)
+		}
+	}
+}
+
+func (ic *InformerCache) Status() InformerCacheStatus {
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+
+	status := InformerCacheStatus{
+		Clusters:       make([]InformerCacheClusterStatus, 0),
+		HeapAllocBytes: memStats.HeapAlloc,
+	}
+	if ic == nil {
+		return status
+	}
+	status.Enabled = ic.enabled
+	status.IdleTimeoutMinutes = int(ic.idleTimeout.Minutes())
+
+	// LastAccess is written by Cluster under the lock, so it is copied before the lock is released
+	type clusterAccess struct {
+		ci         *ClusterInformer
+		lastAccess time.Time
+	}
+	ic.mu.Lock()
+	clusters := make([]clusterAccess, 0, len(ic.clusters))
+	for _, ci := range ic.clusters {
+		clusters = append(clusters, clusterAccess{ci: ci, lastAccess: ci.LastAccess})
+	}
+	ic.mu.Unlock()
+
+	for _, access := range clusters {
+		ci := access.ci
+		clusterStatus := InformerCacheClusterStatus{
+			ID:         ci.ID,
+			Name:       ci.Name,
+			Synced:     ci.Synced(),
+			Started:    ElapsedTimeShort(ci.Started),
+			LastAccess: ElapsedTimeShort(access.lastAccess),
+			Kinds:      make([]InformerCacheKindStatus, 0),
+		}
+		for kind, informer := range ci.Informers {
+			kindStatus := InformerCacheKindStatus{
+				Kind:   kind,
+				Synced: informer.HasSynced(),
+			}
+			for _, obj := range informer.GetStore().List() {
+				kindStatus.Objects++
+				// API types are protobuf messages, their encoded size is a cheap approximation of the memory they hold
+				if sized, ok := obj.(interface{ Size() int }); ok {
+					kindStatus.Bytes += sized.Size()
+				}
+			}
+			clusterStatus.Objects += kindStatus.Objects
+			clusterStatus.Bytes += kindStatus.Bytes
+			clusterStatus.Kinds = append(clusterStatus.Kinds, kindStatus)
+		}
+		sort.Slice(clusterStatus.Kinds, func(i, j int) bool {
+			return clusterStatus.Kinds[i].Kind < clusterStatus.Kinds[j].Kind
+		})
+		status.Clusters = append(status.Clusters, clusterStatus)
+	}
+	sort.Slice(status.Clusters, func(i, j int) bool {
+		return status.Clusters[i].ID+status.Clusters[i].Name < status.Clusters[j].ID+status.Clusters[j].Name
+	})
+
+	return status
+}
+
+// Synced reports whether every informer of the cluster has completed its initial list.
+func (ci *ClusterInformer) Synced() bool {
+	if ci == nil {
+		return false
+	}
+	for _, informer := range ci.Informers {
+		if !informer.HasSynced() {
+			return false
+		}
+	}
+	return true
+}
+
+func (ci *ClusterInformer) stop() {
+	close(ci.stopCh)
+	ci.Factory.Shutdown()
+}
+
+// fromLister copies objects returned by a lister and orders them by namespace and name like the API server does.
+// Listers hand out pointers into the shared cache, so the objects must be treated as read-only.
+func fromLister[T any, PT interface {
+	*T
+	metav1.Object
+}](items []PT) []T {
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].GetNamespace() != items[j].GetNamespace() {
+			return items[i].GetNamespace() < items[j].GetNamespace()
+		}
+		return items[i].GetName() < items[j].GetName()
+	})
+	result := make([]T, 0, len(items))
+	for _, item := range items {
+		result = append(result, *item)
+	}
+	return result
+}
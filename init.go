@@ -21,6 +21,12 @@ const (
 	SessionsCollection    = "sessions"
 	KubeconfigsCollection = "kubeconfigs"
 	ActivityConsole       = "activity_console"
+	AuditCollection       = "audit"
+	ExecTranscripts       = "exec_transcripts"
+	UsageSamples          = "usage_samples"
+
+	HttpSessionName = "session"
+	FrontendOrigin  = "http://localhost:3000"
 
 	HttpSessionDurationSeconds = 432000
 )
@@ -34,6 +40,12 @@ var (
 	DataDirectory     string
 	DBHelper          *DatabaseHelper
 	ConfigurationMode bool
+	CacheSettings     CacheConfig
+	K8sCache          *InformerCache
+	DefaultRole       string
+	SecurityUsers     map[string]UserConfig
+	UsageSettings     UsageConfig
+	QuotaSettings     QuotaConfig
 )
 
 func init() {
@@ -70,6 +82,36 @@ func init() {
 	if config.Log.MaxAge == 0 {
 		config.Log.MaxAge = 7
 	}
+	if config.Cache.IdleTimeoutMinutes == 0 {
+		config.Cache.IdleTimeoutMinutes = 15
+	}
+	CacheSettings = config.Cache
+	if _, ok := roleRanks[config.Security.DefaultRole]; !ok {
+		config.Security.DefaultRole = RoleViewer
+	}
+	DefaultRole = config.Security.DefaultRole
+	SecurityUsers = make(map[string]UserConfig)
+	for _, user := range config.Security.Users {
+		if _, ok := roleRanks[user.Role]; !ok {
+			user.Role = RoleViewer
+		}
+		SecurityUsers[user.Login] = user
+	}
+	if config.Usage.IntervalSeconds == 0 {
+		config.Usage.IntervalSeconds = 60
+	}
+	if config.Usage.RetentionDays == 0 {
+		config.Usage.RetentionDays = 7
+	}
+	if config.Usage.MaxPoints == 0 {
+		config.Usage.MaxPoints = 300
+	}
+	UsageSettings = config.Usage
+	if config.Quota.IntervalMinutes == 0 {
+		config.Quota.IntervalMinutes = 5
+	}
+	QuotaSettings = config.Quota
+
 	logger.SetFormatter(&logger.JSONFormatter{})
 	lumberjackLogger := &lumberjack.Logger{
 		Filename:   LogDirectory + PathSeparator + AppLogFile,
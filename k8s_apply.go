@@ -0,0 +1,218 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/pmezard/go-difflib/difflib"
+	"gopkg.in/yaml.v3"
+	v1core "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	"strings"
+)
+
+// FieldManager owns the fields set through server-side apply by this tool
+const FieldManager = "k8s-monitoring"
+
+// ApplyConflict is a field another manager owns, found in the causes of a conflict error
+type ApplyConflict struct {
+	Field   string `json:"field"`
+	Manager string `json:"manager"`
+	Message string `json:"message"`
+}
+
+type ApplyResult struct {
+	Object  *unstructured.Unstructured
+	Live    *unstructured.Unstructured
+	Created bool
+}
+
+// Applier applies manifests of any kind the cluster serves
+type Applier struct {
+	client dynamic.Interface
+	mapper meta.RESTMapper
+}
+
+func NewApplier(config *rest.Config, clientset *kubernetes.Clientset) (*Applier, error) {
+	client, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return &Applier{
+		client: client,
+		mapper: restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(clientset.Discovery())),
+	}, nil
+}
+
+// ParseManifest reads a single object from YAML or JSON. Fields the server maintains are dropped,
+// otherwise editing the output of a detail view would claim ownership of them.
+func ParseManifest(manifest string) (*unstructured.Unstructured, error) {
+	data, err := utilyaml.ToJSON([]byte(manifest))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse manifest: %v", err)
+	}
+	object := &unstructured.Unstructured{}
+	if err := object.UnmarshalJSON(data); err != nil {
+		return nil, fmt.Errorf("unable to parse manifest: %v", err)
+	}
+	if object.GetName() == "" {
+		return nil, errors.New("metadata.name is required")
+	}
+	object.SetManagedFields(nil)
+	object.SetCreationTimestamp(metav1.Time{})
+	object.SetGeneration(0)
+	unstructured.RemoveNestedField(object.Object, "status")
+	return object, nil
+}
+
+// ErrApplyForbidden is returned when the role of the session may not apply the kind
+var ErrApplyForbidden = errors.New("forbidden")
+
+// ApplyRequiredRole is the role needed to apply a kind. Cluster-scoped kinds and RBAC objects
+// are limited to admins, operators could otherwise grant themselves any permission.
+func ApplyRequiredRole(mapping *meta.RESTMapping) string {
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace || mapping.GroupVersionKind.Group == rbacv1.GroupName {
+		return RoleAdmin
+	}
+	return RoleOperator
+}
+
+// Resolve maps the object to its resource and checks role may apply it, ns is set as the namespace of
+// namespaced objects which name none. Kinds the cluster does not serve are returned as no match errors,
+// see meta.IsNoMatchError.
+func (a *Applier) Resolve(object *unstructured.Unstructured, ns, role string) (dynamic.ResourceInterface, error) {
+	gvk := object.GroupVersionKind()
+	mapping, err := a.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, err
+	}
+	if required := ApplyRequiredRole(mapping); roleRanks[role] < roleRanks[required] {
+		return nil, fmt.Errorf("%w: applying %s requires the %s role", ErrApplyForbidden, gvk.Kind, required)
+	}
+
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		object.SetNamespace("")
+		return a.client.Resource(mapping.Resource), nil
+	}
+	if object.GetNamespace() == "" {
+		object.SetNamespace(ns)
+	}
+	return a.client.Resource(mapping.Resource).Namespace(object.GetNamespace()), nil
+}
+
+// Apply runs server-side apply of an object resolved with Resolve
+func Apply(ctx context.Context, resource dynamic.ResourceInterface, object *unstructured.Unstructured, force, dryRun bool) (*ApplyResult, error) {
+	result := &ApplyResult{}
+	live, err := resource.Get(ctx, object.GetName(), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		result.Created = true
+	} else if err != nil {
+		return nil, err
+	} else {
+		result.Live = live
+	}
+
+	data, err := object.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	opts := metav1.PatchOptions{FieldManager: FieldManager, Force: &force}
+	if dryRun {
+		opts.DryRun = []string{metav1.DryRunAll}
+	}
+	result.Object, err = resource.Patch(ctx, object.GetName(), types.ApplyPatchType, data, opts)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ApplyConflicts lists the fields owned by other managers when apply failed with a conflict
+func ApplyConflicts(err error) []ApplyConflict {
+	result := make([]ApplyConflict, 0)
+	var status apierrors.APIStatus
+	if !apierrors.IsConflict(err) || !errors.As(err, &status) || status.Status().Details == nil {
+		return result
+	}
+	for _, cause := range status.Status().Details.Causes {
+		if cause.Type != metav1.CauseTypeFieldManagerConflict {
+			continue
+		}
+		// Messages look like: conflict with "kubectl-client-side-apply" using apps/v1
+		manager := cause.Message
+		if _, rest, ok := strings.Cut(cause.Message, `conflict with "`); ok {
+			manager, _, _ = strings.Cut(rest, `"`)
+		}
+		result = append(result, ApplyConflict{
+			Field:   cause.Field,
+			Manager: manager,
+			Message: cause.Message,
+		})
+	}
+	return result
+}
+
+// RedactedValue replaces the values of Secrets in rendered objects
+const RedactedValue = "<redacted>"
+
+// ObjectYAML renders an object for diffs, without managed fields and fields changing on every write.
+// Secret values are redacted like in the Secret views.
+func ObjectYAML(object *unstructured.Unstructured) (string, error) {
+	if object == nil {
+		return "", nil
+	}
+	object = object.DeepCopy()
+	object.SetManagedFields(nil)
+	object.SetResourceVersion("")
+	object.SetGeneration(0)
+	if object.GroupVersionKind().GroupKind() == (schema.GroupKind{Kind: "Secret"}) {
+		RedactSecret(object.Object)
+	}
+	yamlStr, err := yaml.Marshal(object.Object)
+	if err != nil {
+		return "", err
+	}
+	return string(yamlStr), nil
+}
+
+// UnifiedDiff returns a unified diff of two texts with three lines of context
+func UnifiedDiff(from, to, fromName, toName string) (string, error) {
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(from),
+		B:        difflib.SplitLines(to),
+		FromFile: fromName,
+		ToFile:   toName,
+		Context:  3,
+	})
+}
+
+// RedactSecret replaces the values of a Secret given as a map, e.g. from an unstructured object or a manifest,
+// and drops the annotation where kubectl apply keeps the whole object
+func RedactSecret(secret map[string]interface{}) {
+	for _, field := range []string{"data", "stringData"} {
+		values, ok := secret[field].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for key := range values {
+			values[key] = RedactedValue
+		}
+	}
+	if metadata, ok := secret["metadata"].(map[string]interface{}); ok {
+		if annotations, ok := metadata["annotations"].(map[string]interface{}); ok {
+			delete(annotations, v1core.LastAppliedConfigAnnotation)
+		}
+	}
+}
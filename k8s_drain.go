@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	v1core "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"sync"
+	"time"
+)
+
+const (
+	NodeActivityType = "Node"
+	// DefaultDrainTimeout bounds a drain when no timeout is given, kubectl drain would wait forever
+	DefaultDrainTimeout = 10 * time.Minute
+	// drainRetryInterval is the wait before retrying an eviction refused by a PodDisruptionBudget
+	drainRetryInterval  = 5 * time.Second
+	mirrorPodAnnotation = "kubernetes.io/config.mirror"
+)
+
+// DrainOptions are the options of kubectl drain
+type DrainOptions struct {
+	IgnoreDaemonSets   bool
+	DeleteEmptyDirData bool
+	// Force evicts pods not managed by a controller, they are not recreated anywhere
+	Force       bool
+	GracePeriod *int64
+	Timeout     time.Duration
+	DryRun      bool
+}
+
+// DrainPod is a pod on a drained node with the reason it is skipped or blocks the drain
+type DrainPod struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Skipped   bool   `json:"skipped"`
+	Blocking  bool   `json:"blocking"`
+	Reason    string `json:"reason"`
+}
+
+// CordonNode marks a node unschedulable or schedulable again, it returns false when the node already was
+func CordonNode(ctx context.Context, clientset *kubernetes.Clientset, name string, unschedulable bool) (bool, error) {
+	node, err := clientset.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+	if node.Spec.Unschedulable == unschedulable {
+		return false, nil
+	}
+	patch := fmt.Sprintf(`{"spec":{"unschedulable":%t}}`, unschedulable)
+	_, err = clientset.CoreV1().Nodes().Patch(ctx, name, types.StrategicMergePatchType, []byte(patch), metav1.PatchOptions{})
+	return err == nil, err
+}
+
+// NodeDrainPods lists the pods on a node and decides which of them are evicted following the filters of kubectl drain
+func NodeDrainPods(ctx context.Context, clientset *kubernetes.Clientset, node string, options DrainOptions) ([]v1core.Pod, []DrainPod, error) {
+	pods, err := clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{FieldSelector: fields.OneTermEqualSelector("spec.nodeName", node).String()})
+	if err != nil {
+		return nil, nil, err
+	}
+	evict := make([]v1core.Pod, 0)
+	result := make([]DrainPod, 0)
+	for _, pod := range pods.Items {
+		drainPod := DrainPod{Name: pod.Name, Namespace: pod.Namespace}
+		drainPod.Skipped, drainPod.Blocking, drainPod.Reason = drainFilter(pod, options)
+		result = append(result, drainPod)
+		if !drainPod.Skipped && !drainPod.Blocking {
+			evict = append(evict, pod)
+		}
+	}
+	return evict, result, nil
+}
+
+// drainFilter mirrors the pod filters of kubectl drain
+func drainFilter(pod v1core.Pod, options DrainOptions) (skipped bool, blocking bool, reason string) {
+	if pod.DeletionTimestamp != nil {
+		return true, false, "pod is terminating"
+	}
+	if _, ok := pod.Annotations[mirrorPodAnnotation]; ok {
+		return true, false, "mirror pod of a static pod"
+	}
+	if pod.Status.Phase == v1core.PodSucceeded || pod.Status.Phase == v1core.PodFailed {
+		return false, false, ""
+	}
+	controller := metav1.GetControllerOf(&pod)
+	if controller != nil && controller.Kind == "DaemonSet" {
+		if !options.IgnoreDaemonSets {
+			return false, true, "managed by DaemonSet " + controller.Name
+		}
+		return true, false, "managed by DaemonSet " + controller.Name
+	}
+	if controller == nil && !options.Force {
+		return false, true, "not managed by a controller"
+	}
+	for _, volume := range pod.Spec.Volumes {
+		if volume.EmptyDir != nil && !options.DeleteEmptyDirData {
+			return false, true, "uses emptyDir volume " + volume.Name
+		}
+	}
+	return false, false, ""
+}
+
+// DrainNode evicts pods in parallel. Evictions refused by a PodDisruptionBudget are retried until the timeout,
+// every pod is then followed until it is gone. Progress is logged to the operation.
+func DrainNode(clientset *kubernetes.Clientset, pods []v1core.Pod, options DrainOptions, operation *ActivityOperation) error {
+	timeout := options.Timeout
+	if timeout <= 0 {
+		timeout = DefaultDrainTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	var failed []error
+	for _, pod := range pods {
+		wg.Add(1)
+		go func(pod v1core.Pod) {
+			defer wg.Done()
+			if err := drainPod(ctx, clientset, pod, options, operation); err != nil {
+				operation.Log(ActivityProgressing, fmt.Sprintf("pod %s/%s: %v", pod.Namespace, pod.Name, err))
+				mutex.Lock()
+				failed = append(failed, err)
+				mutex.Unlock()
+			}
+		}(pod)
+	}
+	wg.Wait()
+
+	if len(failed) > 0 {
+		return fmt.Errorf("%d of %d pods could not be evicted: %w", len(failed), len(pods), errors.Join(failed...))
+	}
+	return nil
+}
+
+func drainPod(ctx context.Context, clientset *kubernetes.Clientset, pod v1core.Pod, options DrainOptions, operation *ActivityOperation) error {
+	for {
+		err := EvictPod(ctx, clientset, pod, options.GracePeriod, options.DryRun)
+		if err == nil || apierrors.IsNotFound(err) {
+			break
+		}
+		var evictionErr *EvictionError
+		if !errors.As(err, &evictionErr) {
+			return err
+		}
+		operation.Log(ActivityProgressing, evictionErr.Error()+", retrying")
+		select {
+		case <-ctx.Done():
+			return evictionErr
+		case <-time.After(drainRetryInterval):
+		}
+	}
+	if options.DryRun {
+		operation.Log(ActivityProgressing, fmt.Sprintf("pod %s/%s can be evicted", pod.Namespace, pod.Name))
+		return nil
+	}
+	operation.Log(ActivityProgressing, fmt.Sprintf("evicting pod %s/%s", pod.Namespace, pod.Name))
+
+	// A pod recreated with the same name by a StatefulSet has another UID
+	for {
+		current, err := clientset.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) || (err == nil && current.UID != pod.UID) {
+			operation.Log(ActivityProgressing, fmt.Sprintf("pod %s/%s evicted", pod.Namespace, pod.Name))
+			return nil
+		}
+		if err != nil && ctx.Err() == nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for pod %s/%s to terminate", pod.Namespace, pod.Name)
+		case <-time.After(time.Second):
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+	"sort"
+	"time"
+)
+
+type ObjectEvent struct {
+	Type      string    `json:"type"`
+	Reason    string    `json:"reason"`
+	Message   string    `json:"message"`
+	Count     int32     `json:"count"`
+	FirstSeen time.Time `json:"first_seen"`
+	LastSeen  time.Time `json:"last_seen"`
+	Age       string    `json:"age"`
+	Source    string    `json:"source"`
+	Object    string    `json:"object"`
+}
+
+// ownedKinds lists the kinds whose events are collected together with the events of their owner
+var ownedKinds = map[string][]string{
+	"Deployment":            {"ReplicaSet"},
+	"ReplicaSet":            {"Pod"},
+	"StatefulSet":           {"Pod"},
+	"DaemonSet":             {"Pod"},
+	"ReplicationController": {"Pod"},
+	"Job":                   {"Pod"},
+	"CronJob":               {"Job"},
+}
+
+// CollectObjectEvents returns the events of an object and of the objects it owns, e.g. the ReplicaSets
+// and Pods of a Deployment, ordered from the oldest to the most recent.
+func CollectObjectEvents(clientset *kubernetes.Clientset, ns, kind, name string) ([]ObjectEvent, error) {
+	involved := []KubernetesResource{{ResourceName: name, ResourceType: kind, ResourceNamespace: ns}}
+	listed := make(map[string][]ownedObject)
+	for i := 0; i < len(involved); i++ {
+		owned, err := ownedResources(clientset, involved[i], listed)
+		if err != nil {
+			return nil, err
+		}
+		involved = append(involved, owned...)
+	}
+
+	result := make([]ObjectEvent, 0)
+	for _, object := range involved {
+		selector := fields.Set{
+			"involvedObject.kind": object.ResourceType,
+			"involvedObject.name": object.ResourceName,
+		}.AsSelector().String()
+		events, err := clientset.CoreV1().Events(ns).List(context.Background(), metav1.ListOptions{FieldSelector: selector})
+		if err != nil {
+			return nil, fmt.Errorf("unable to list events of %s %s: %v", object.ResourceType, object.ResourceName, err)
+		}
+		for _, event := range events.Items {
+			result = append(result, NewObjectEvent(event))
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].LastSeen.Before(result[j].LastSeen)
+	})
+
+	return result, nil
+}
+
+func NewObjectEvent(event v1.Event) ObjectEvent {
+	firstSeen := event.FirstTimestamp.Time
+	if firstSeen.IsZero() {
+		firstSeen = event.EventTime.Time
+	}
+	if firstSeen.IsZero() {
+		firstSeen = event.CreationTimestamp.Time
+	}
+
+	lastSeen := event.LastTimestamp.Time
+	count := event.Count
+	if event.Series != nil {
+		if lastSeen.IsZero() {
+			lastSeen = event.Series.LastObservedTime.Time
+		}
+		if count == 0 {
+			count = event.Series.Count
+		}
+	}
+	if lastSeen.IsZero() {
+		lastSeen = firstSeen
+	}
+	if count == 0 {
+		count = 1
+	}
+
+	source := event.Source.Component
+	if source == "" {
+		source = event.ReportingController
+	}
+	host := event.Source.Host
+	if host == "" {
+		host = event.ReportingInstance
+	}
+	if host != "" {
+		source += ", " + host
+	}
+
+	return ObjectEvent{
+		Type:      event.Type,
+		Reason:    event.Reason,
+		Message:   event.Message,
+		Count:     count,
+		FirstSeen: firstSeen,
+		LastSeen:  lastSeen,
+		Age:       ElapsedTimeShort(lastSeen),
+		Source:    source,
+		Object:    event.InvolvedObject.Kind + "/" + event.InvolvedObject.Name,
+	}
+}
+
+type ownedObject struct {
+	name   string
+	owners []metav1.OwnerReference
+}
+
+// ownedResources finds the objects controlled by the owner, objects of each kind are listed once per collection
+func ownedResources(clientset *kubernetes.Clientset, owner KubernetesResource, listed map[string][]ownedObject) ([]KubernetesResource, error) {
+	var result []KubernetesResource
+	for _, kind := range ownedKinds[owner.ResourceType] {
+		objects, ok := listed[kind]
+		if !ok {
+			switch kind {
+			case "ReplicaSet":
+				replicaSets, err := clientset.AppsV1().ReplicaSets(owner.ResourceNamespace).List(context.Background(), metav1.ListOptions{})
+				if err != nil {
+					return nil, fmt.Errorf("unable to list replica sets: %v", err)
+				}
+				for _, rs := range replicaSets.Items {
+					objects = append(objects, ownedObject{name: rs.Name, owners: rs.OwnerReferences})
+				}
+			case "Job":
+				jobs, err := clientset.BatchV1().Jobs(owner.ResourceNamespace).List(context.Background(), metav1.ListOptions{})
+				if err != nil {
+					return nil, fmt.Errorf("unable to list jobs: %v", err)
+				}
+				for _, job := range jobs.Items {
+					objects = append(objects, ownedObject{name: job.Name, owners: job.OwnerReferences})
+				}
+			case "Pod":
+				pods, err := clientset.CoreV1().Pods(owner.ResourceNamespace).List(context.Background(), metav1.ListOptions{})
+				if err != nil {
+					return nil, fmt.Errorf("unable to list pods: %v", err)
+				}
+				for _, pod := range pods.Items {
+					objects = append(objects, ownedObject{name: pod.Name, owners: pod.OwnerReferences})
+				}
+			}
+			listed[kind] = objects
+		}
+
+		for _, object := range objects {
+			for _, ref := range object.owners {
+				if ref.Kind == owner.ResourceType && ref.Name == owner.ResourceName {
+					result = append(result, KubernetesResource{ResourceName: object.name, ResourceType: kind, ResourceNamespace: owner.ResourceNamespace})
+					break
+				}
+			}
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	logger "github.com/sirupsen/logrus"
+	v1core "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	"strings"
+)
+
+// PDBStatus tells why a PodDisruptionBudget refuses an eviction
+type PDBStatus struct {
+	Name               string `json:"name"`
+	DisruptionsAllowed int32  `json:"disruptions_allowed"`
+	CurrentHealthy     int32  `json:"current_healthy"`
+	DesiredHealthy     int32  `json:"desired_healthy"`
+	ExpectedPods       int32  `json:"expected_pods"`
+}
+
+// EvictionError is returned when the eviction is refused for now, by a PodDisruptionBudget when PDBs is set
+type EvictionError struct {
+	Pod     string
+	Message string
+	PDBs    []PDBStatus
+}
+
+func (e *EvictionError) Error() string {
+	names := make([]string, 0)
+	for _, pdb := range e.PDBs {
+		names = append(names, fmt.Sprintf("%s (%d disruptions allowed, %d of %d healthy)", pdb.Name, pdb.DisruptionsAllowed, pdb.CurrentHealthy, pdb.DesiredHealthy))
+	}
+	if len(names) == 0 {
+		return fmt.Sprintf("cannot evict pod %s: %s", e.Pod, e.Message)
+	}
+	return fmt.Sprintf("cannot evict pod %s as it would violate the pod's disruption budget %s", e.Pod, strings.Join(names, ", "))
+}
+
+// EvictPod evicts through the Eviction API so PodDisruptionBudgets are respected. Every 429 is returned
+// as *EvictionError so callers can retry, PDBs lists the budgets selecting the pod and is empty when the
+// refusal is throttling by the API server or the budgets could not be listed.
+func EvictPod(ctx context.Context, clientset *kubernetes.Clientset, pod v1core.Pod, gracePeriod *int64, dryRun bool) error {
+	deleteOptions := &metav1.DeleteOptions{GracePeriodSeconds: gracePeriod}
+	if dryRun {
+		deleteOptions.DryRun = []string{metav1.DryRunAll}
+	}
+	err := clientset.PolicyV1().Evictions(pod.Namespace).Evict(ctx, &policyv1.Eviction{
+		ObjectMeta:    metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace},
+		DeleteOptions: deleteOptions,
+	})
+	if err == nil || !apierrors.IsTooManyRequests(err) {
+		return err
+	}
+	pdbs, pdbErr := PodDisruptionBudgetsFor(ctx, clientset, pod)
+	if pdbErr != nil {
+		logger.Warnf("Failed to list disruption budgets of pod %s/%s: %v", pod.Namespace, pod.Name, pdbErr)
+		pdbs = make([]PDBStatus, 0)
+	}
+	return &EvictionError{
+		Pod:     pod.Name,
+		Message: err.Error(),
+		PDBs:    pdbs,
+	}
+}
+
+// PodDisruptionBudgetsFor lists the budgets selecting a pod
+func PodDisruptionBudgetsFor(ctx context.Context, clientset *kubernetes.Clientset, pod v1core.Pod) ([]PDBStatus, error) {
+	pdbs, err := clientset.PolicyV1().PodDisruptionBudgets(pod.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]PDBStatus, 0)
+	for _, pdb := range pdbs.Items {
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil || selector.Empty() {
+			continue
+		}
+		if !selector.Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		result = append(result, PDBStatus{
+			Name:               pdb.Name,
+			DisruptionsAllowed: pdb.Status.DisruptionsAllowed,
+			CurrentHealthy:     pdb.Status.CurrentHealthy,
+			DesiredHealthy:     pdb.Status.DesiredHealthy,
+			ExpectedPods:       pdb.Status.ExpectedPods,
+		})
+	}
+	return result, nil
+}
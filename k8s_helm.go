@@ -0,0 +1,297 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	logger "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+	"io"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Helm 3 stores every revision of a release in a Secret (or a ConfigMap with the configmap driver)
+// labeled owner=helm, the payload is base64 encoded gzipped JSON under the "release" key
+const (
+	HelmOwnerSelector = "owner=helm"
+	HelmReleaseKey    = "release"
+)
+
+type HelmReleaseInfo struct {
+	FirstDeployed time.Time `json:"first_deployed"`
+	LastDeployed  time.Time `json:"last_deployed"`
+	Deleted       time.Time `json:"deleted"`
+	Description   string    `json:"description"`
+	Status        string    `json:"status"`
+	Notes         string    `json:"notes"`
+}
+
+type HelmChartMetadata struct {
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	AppVersion  string `json:"appVersion"`
+	Description string `json:"description"`
+	Icon        string `json:"icon"`
+}
+
+type HelmChart struct {
+	Metadata HelmChartMetadata      `json:"metadata"`
+	Values   map[string]interface{} `json:"values"`
+}
+
+// HelmRelease is the part of Helm's release record shown by the tool
+type HelmRelease struct {
+	Name      string                 `json:"name"`
+	Info      HelmReleaseInfo        `json:"info"`
+	Chart     HelmChart              `json:"chart"`
+	Config    map[string]interface{} `json:"config"`
+	Manifest  string                 `json:"manifest"`
+	Version   int                    `json:"version"`
+	Namespace string                 `json:"namespace"`
+	// Storage is the kind of object the release was read from
+	Storage string `json:"-"`
+}
+
+// HelmManifestResource is an object rendered by a release
+type HelmManifestResource struct {
+	APIVersion string `json:"api_version"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	Namespace  string `json:"namespace"`
+}
+
+var gzipMagic = []byte{0x1f, 0x8b, 0x08}
+
+// DecodeHelmRelease decodes the payload of a release Secret or ConfigMap
+func DecodeHelmRelease(data string) (*HelmRelease, error) {
+	payload, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode release: %v", err)
+	}
+	// Helm 3 always gzips releases, like Helm itself uncompressed JSON is still accepted when the magic bytes are missing
+	if bytes.HasPrefix(payload, gzipMagic) {
+		reader, err := gzip.NewReader(bytes.NewReader(payload))
+		if err != nil {
+			return nil, fmt.Errorf("unable to decompress release: %v", err)
+		}
+		defer reader.Close()
+		payload, err = io.ReadAll(reader)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decompress release: %v", err)
+		}
+	}
+	var release HelmRelease
+	if err := json.Unmarshal(payload, &release); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal release: %v", err)
+	}
+	return &release, nil
+}
+
+// ListHelmReleases decodes every stored revision of every release in ns, sorted by name and revision
+func ListHelmReleases(clientset *kubernetes.Clientset, ns string) ([]HelmRelease, error) {
+	return listHelmReleases(clientset, ns, HelmOwnerSelector)
+}
+
+// HelmReleaseHistory decodes the stored revisions of one release, sorted by revision
+func HelmReleaseHistory(clientset *kubernetes.Clientset, ns, name string) ([]HelmRelease, error) {
+	// Helm labels its records with the release name, so only the records of the release are read
+	selector, err := labels.ValidatedSelectorFromSet(labels.Set{"owner": "helm", "name": name})
+	if err != nil {
+		return nil, fmt.Errorf("invalid release name %q: %v", name, err)
+	}
+	return listHelmReleases(clientset, ns, selector.String())
+}
+
+func listHelmReleases(clientset *kubernetes.Clientset, ns, selector string) ([]HelmRelease, error) {
+	result := make([]HelmRelease, 0)
+	opts := metav1.ListOptions{LabelSelector: selector}
+
+	secrets, err := clientset.CoreV1().Secrets(ns).List(context.Background(), opts)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list release secrets: %v", err)
+	}
+	for _, secret := range secrets.Items {
+		if !strings.HasPrefix(secret.Name, "sh.helm.release.v1.") {
+			continue
+		}
+		release, err := DecodeHelmRelease(string(secret.Data[HelmReleaseKey]))
+		if err != nil {
+			// One broken record should not hide every other release
+			logger.Warnf("Skipping Helm release secret %s/%s: %v", secret.Namespace, secret.Name, err)
+			continue
+		}
+		release.Storage = "Secret"
+		result = append(result, *release)
+	}
+
+	configMaps, err := clientset.CoreV1().ConfigMaps(ns).List(context.Background(), opts)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list release config maps: %v", err)
+	}
+	for _, configMap := range configMaps.Items {
+		if !strings.HasPrefix(configMap.Name, "sh.helm.release.v1.") {
+			continue
+		}
+		release, err := DecodeHelmRelease(configMap.Data[HelmReleaseKey])
+		if err != nil {
+			logger.Warnf("Skipping Helm release config map %s/%s: %v", configMap.Namespace, configMap.Name, err)
+			continue
+		}
+		release.Storage = "ConfigMap"
+		result = append(result, *release)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Namespace != result[j].Namespace {
+			return result[i].Namespace < result[j].Namespace
+		}
+		if result[i].Name != result[j].Name {
+			return result[i].Name < result[j].Name
+		}
+		return result[i].Version < result[j].Version
+	})
+	return result, nil
+}
+
+// LatestHelmReleases keeps the highest revision of each release, releases must be sorted like ListHelmReleases does
+func LatestHelmReleases(releases []HelmRelease) []HelmRelease {
+	result := make([]HelmRelease, 0)
+	for i, release := range releases {
+		if i+1 < len(releases) && releases[i+1].Name == release.Name && releases[i+1].Namespace == release.Namespace {
+			continue
+		}
+		result = append(result, release)
+	}
+	return result
+}
+
+// HelmManifestResources lists the objects of a rendered manifest, objects without a namespace go to the release namespace
+func HelmManifestResources(manifest, ns string) ([]HelmManifestResource, error) {
+	result := make([]HelmManifestResource, 0)
+	decoder := yaml.NewDecoder(strings.NewReader(manifest))
+	for {
+		var object struct {
+			APIVersion string `yaml:"apiVersion"`
+			Kind       string `yaml:"kind"`
+			Metadata   struct {
+				Name      string `yaml:"name"`
+				Namespace string `yaml:"namespace"`
+			} `yaml:"metadata"`
+		}
+		err := decoder.Decode(&object)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse manifest: %v", err)
+		}
+		if object.Kind == "" {
+			// Empty documents of templates which rendered nothing
+			continue
+		}
+		resource := HelmManifestResource{
+			APIVersion: object.APIVersion,
+			Kind:       object.Kind,
+			Name:       object.Metadata.Name,
+			Namespace:  object.Metadata.Namespace,
+		}
+		if resource.Namespace == "" {
+			resource.Namespace = ns
+		}
+		result = append(result, resource)
+	}
+	return result, nil
+}
+
+var manifestSeparator = regexp.MustCompile(`(?m)^---[ \t]*$`)
+
+// RedactHelmRelease replaces the values of Secrets rendered by the release like the Secret views hide them,
+// in the manifest as well as where they appear in the user supplied and chart default values
+func RedactHelmRelease(release *HelmRelease) error {
+	secretValues := make(map[string]bool)
+	documents := manifestSeparator.Split(release.Manifest, -1)
+	for i, document := range documents {
+		var node yaml.Node
+		if err := yaml.Unmarshal([]byte(document), &node); err != nil {
+			return fmt.Errorf("unable to parse manifest: %v", err)
+		}
+		if len(node.Content) == 0 || node.Content[0].Kind != yaml.MappingNode || mappingValue(node.Content[0], "kind") != "Secret" {
+			continue
+		}
+		object := node.Content[0]
+		for j := 0; j+1 < len(object.Content); j += 2 {
+			field, values := object.Content[j].Value, object.Content[j+1]
+			if (field != "data" && field != "stringData") || values.Kind != yaml.MappingNode {
+				continue
+			}
+			for k := 1; k < len(values.Content); k += 2 {
+				value := values.Content[k].Value
+				if field == "data" {
+					if decoded, err := base64.StdEncoding.DecodeString(value); err == nil {
+						value = string(decoded)
+					}
+				}
+				if value != "" {
+					secretValues[value] = true
+				}
+				values.Content[k] = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: RedactedValue}
+			}
+		}
+		var redacted bytes.Buffer
+		encoder := yaml.NewEncoder(&redacted)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(&node); err != nil {
+			return err
+		}
+		if err := encoder.Close(); err != nil {
+			return err
+		}
+		documents[i] = "\n" + redacted.String()
+	}
+	release.Manifest = strings.Join(documents, "---")
+
+	redactValues(release.Config, secretValues)
+	redactValues(release.Chart.Values, secretValues)
+	return nil
+}
+
+func mappingValue(mapping *yaml.Node, key string) string {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1].Value
+		}
+	}
+	return ""
+}
+
+// redactValues replaces the strings of Helm values which ended up in a Secret
+func redactValues(values interface{}, secretValues map[string]bool) {
+	switch v := values.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if str, ok := value.(string); ok && secretValues[str] {
+				v[key] = RedactedValue
+			} else {
+				redactValues(value, secretValues)
+			}
+		}
+	case []interface{}:
+		for i, value := range v {
+			if str, ok := value.(string); ok && secretValues[str] {
+				v[i] = RedactedValue
+			} else {
+				redactValues(value, secretValues)
+			}
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package main
 
 import (
+	logger "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
 	"k8s.io/client-go/tools/clientcmd"
 )
 
@@ -22,3 +24,28 @@ func SelectClusterContext(config []byte, name string) ([]byte, error) {
 	}
 	return result, nil
 }
+
+type ClusterRef struct {
+	ID   string
+	Name string
+}
+
+// ListClusters returns every cluster of the stored kubeconfigs, as GetKubeconfigsHandler lists them
+func ListClusters() ([]ClusterRef, error) {
+	var k8sConfigs []Kubeconfig
+	if err := DBHelper.FindAll(KubeconfigsCollection, bson.M{}, &k8sConfigs); err != nil {
+		return nil, err
+	}
+	result := make([]ClusterRef, 0)
+	for _, k8sConfig := range k8sConfigs {
+		config, err := clientcmd.Load([]byte(k8sConfig.Content))
+		if err != nil {
+			logger.Warnf("Failed to parse kubeconfig %s: %v", k8sConfig.Name, err)
+			continue
+		}
+		for name := range config.Clusters {
+			result = append(result, ClusterRef{ID: k8sConfig.ID.Hex(), Name: name})
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+
+	v1apps "k8s.io/api/apps/v1"
+	v1batch "k8s.io/api/batch/v1"
+	v1core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+// The List* helpers serve from the informer cache of the cluster when it is synced
+// and fall back to the API server otherwise.
+
+func ListDeployments(clientset *kubernetes.Clientset, id, name, ns string) ([]v1apps.Deployment, error) {
+	if ci := K8sCache.Cluster(id, name, clientset); ci.Synced() {
+		items, err := ci.Factory.Apps().V1().Deployments().Lister().Deployments(ns).List(labels.Everything())
+		if err == nil {
+			return fromLister(items), nil
+		}
+	}
+	list, err := clientset.AppsV1().Deployments(ns).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+func ListReplicaSets(clientset *kubernetes.Clientset, id, name, ns string) ([]v1apps.ReplicaSet, error) {
+	if ci := K8sCache.Cluster(id, name, clientset); ci.Synced() {
+		items, err := ci.Factory.Apps().V1().ReplicaSets().Lister().ReplicaSets(ns).List(labels.Everything())
+		if err == nil {
+			return fromLister(items), nil
+		}
+	}
+	list, err := clientset.AppsV1().ReplicaSets(ns).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+func ListStatefulSets(clientset *kubernetes.Clientset, id, name, ns string) ([]v1apps.StatefulSet, error) {
+	if ci := K8sCache.Cluster(id, name, clientset); ci.Synced() {
+		items, err := ci.Factory.Apps().V1().StatefulSets().Lister().StatefulSets(ns).List(labels.Everything())
+		if err == nil {
+			return fromLister(items), nil
+		}
+	}
+	list, err := clientset.AppsV1().StatefulSets(ns).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+func ListDaemonSets(clientset *kubernetes.Clientset, id, name, ns string) ([]v1apps.DaemonSet, error) {
+	if ci := K8sCache.Cluster(id, name, clientset); ci.Synced() {
+		items, err := ci.Factory.Apps().V1().DaemonSets().Lister().DaemonSets(ns).List(labels.Everything())
+		if err == nil {
+			return fromLister(items), nil
+		}
+	}
+	list, err := clientset.AppsV1().DaemonSets(ns).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+func ListJobs(clientset *kubernetes.Clientset, id, name, ns string) ([]v1batch.Job, error) {
+	if ci := K8sCache.Cluster(id, name, clientset); ci.Synced() {
+		items, err := ci.Factory.Batch().V1().Jobs().Lister().Jobs(ns).List(labels.Everything())
+		if err == nil {
+			return fromLister(items), nil
+		}
+	}
+	list, err := clientset.BatchV1().Jobs(ns).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+func ListCronJobs(clientset *kubernetes.Clientset, id, name, ns string) ([]v1batch.CronJob, error) {
+	if ci := K8sCache.Cluster(id, name, clientset); ci.Synced() {
+		items, err := ci.Factory.Batch().V1().CronJobs().Lister().CronJobs(ns).List(labels.Everything())
+		if err == nil {
+			return fromLister(items), nil
+		}
+	}
+	list, err := clientset.BatchV1().CronJobs(ns).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+func ListPods(clientset *kubernetes.Clientset, id, name, ns string) ([]v1core.Pod, error) {
+	if ci := K8sCache.Cluster(id, name, clientset); ci.Synced() {
+		items, err := ci.Factory.Core().V1().Pods().Lister().Pods(ns).List(labels.Everything())
+		if err == nil {
+			return fromLister(items), nil
+		}
+	}
+	list, err := clientset.CoreV1().Pods(ns).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+func ListReplicationControllers(clientset *kubernetes.Clientset, id, name, ns string) ([]v1core.ReplicationController, error) {
+	if ci := K8sCache.Cluster(id, name, clientset); ci.Synced() {
+		items, err := ci.Factory.Core().V1().ReplicationControllers().Lister().ReplicationControllers(ns).List(labels.Everything())
+		if err == nil {
+			return fromLister(items), nil
+		}
+	}
+	list, err := clientset.CoreV1().ReplicationControllers(ns).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
@@ -0,0 +1,306 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	"io"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultAggregatedTailLines limits the lines read from each pod when aggregated logs are requested without tailLines or sinceSeconds
+const DefaultAggregatedTailLines = 100
+
+// DefaultLogTailLines limits the lines read from each container when logs are collected without tailLines,
+// otherwise the whole log of a long running container would be held in memory
+const DefaultLogTailLines = 5000
+
+// maxLogLineSize is the longest log line read at once, longer lines are sent in fragments of this size
+const maxLogLineSize = 1024 * 1024
+
+type LogQuery struct {
+	Container    string
+	Previous     string
+	SinceSeconds string
+	TailLines    string
+	Timestamps   string
+	Follow       string
+}
+
+type LogOptions struct {
+	Container    string
+	Previous     bool
+	SinceSeconds int64
+	// TailLines is nil when not given, 0 asks for no lines like kubectl logs --tail=0
+	TailLines  *int64
+	Timestamps bool
+	Follow     bool
+}
+
+type LogLine struct {
+	Pod       string `json:"pod"`
+	Container string `json:"container"`
+	Timestamp string `json:"timestamp,omitempty"`
+	Line      string `json:"line"`
+}
+
+func NewLogQuery(c echo.Context) LogQuery {
+	return LogQuery{
+		Container:    c.QueryParam("container"),
+		Previous:     c.QueryParam("previous"),
+		SinceSeconds: c.QueryParam("sinceSeconds"),
+		TailLines:    c.QueryParam("tailLines"),
+		Timestamps:   c.QueryParam("timestamps"),
+		Follow:       c.QueryParam("follow"),
+	}
+}
+
+// Options validates the query, empty values keep the defaults of the API server
+func (q LogQuery) Options() (LogOptions, error) {
+	options := LogOptions{Container: q.Container}
+	var err error
+	if q.Previous != "" {
+		if options.Previous, err = strconv.ParseBool(q.Previous); err != nil {
+			return options, fmt.Errorf("invalid previous: %v", err)
+		}
+	}
+	if q.Timestamps != "" {
+		if options.Timestamps, err = strconv.ParseBool(q.Timestamps); err != nil {
+			return options, fmt.Errorf("invalid timestamps: %v", err)
+		}
+	}
+	if q.Follow != "" {
+		if options.Follow, err = strconv.ParseBool(q.Follow); err != nil {
+			return options, fmt.Errorf("invalid follow: %v", err)
+		}
+	}
+	if q.SinceSeconds != "" {
+		if options.SinceSeconds, err = strconv.ParseInt(q.SinceSeconds, 10, 64); err != nil || options.SinceSeconds <= 0 {
+			return options, fmt.Errorf("invalid sinceSeconds: %s", q.SinceSeconds)
+		}
+	}
+	if q.TailLines != "" {
+		tailLines, err := strconv.ParseInt(q.TailLines, 10, 64)
+		if err != nil || tailLines < 0 {
+			return options, fmt.Errorf("invalid tailLines: %s", q.TailLines)
+		}
+		options.TailLines = &tailLines
+	}
+	if options.Previous && options.Follow {
+		return options, fmt.Errorf("previous logs cannot be followed")
+	}
+	return options, nil
+}
+
+// PodLogOptions always asks for timestamps, they are needed to interleave lines of several pods
+// and are dropped from the output when not requested.
+func (o LogOptions) PodLogOptions(container string) *v1.PodLogOptions {
+	podLogOptions := &v1.PodLogOptions{
+		Container:  container,
+		Previous:   o.Previous,
+		Follow:     o.Follow,
+		Timestamps: true,
+	}
+	if o.SinceSeconds > 0 {
+		podLogOptions.SinceSeconds = &o.SinceSeconds
+	}
+	if o.TailLines != nil {
+		tailLines := *o.TailLines
+		podLogOptions.TailLines = &tailLines
+	}
+	return podLogOptions
+}
+
+// DefaultContainer picks the container kubectl picks when none is given
+func DefaultContainer(pod v1.Pod) string {
+	if name := pod.Annotations["kubectl.kubernetes.io/default-container"]; name != "" {
+		return name
+	}
+	if len(pod.Spec.Containers) > 0 {
+		return pod.Spec.Containers[0].Name
+	}
+	return ""
+}
+
+// StreamPodLogs reads the logs of a pod container line by line until the stream ends or the context is canceled
+func StreamPodLogs(ctx context.Context, clientset *kubernetes.Clientset, ns, pod, container string, options LogOptions, send func(LogLine) error) error {
+	stream, err := clientset.CoreV1().Pods(ns).GetLogs(pod, options.PodLogOptions(container)).Stream(ctx)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	reader := bufio.NewReaderSize(stream, maxLogLineSize)
+	// Fragments of a long line carry the timestamp of its first fragment
+	var timestamp string
+	continued := false
+	for {
+		fragment, isPrefix, err := reader.ReadLine()
+		if err != nil {
+			if errors.Is(err, io.EOF) || ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		line := LogLine{Pod: pod, Container: container}
+		if continued {
+			line.Timestamp, line.Line = timestamp, string(fragment)
+		} else {
+			line.Timestamp, line.Line = splitLogTimestamp(string(fragment))
+			timestamp = line.Timestamp
+		}
+		continued = isPrefix
+		if !options.Timestamps {
+			line.Timestamp = ""
+		}
+		if err := send(line); err != nil {
+			return err
+		}
+	}
+}
+
+func splitLogTimestamp(text string) (string, string) {
+	timestamp, line, found := strings.Cut(text, " ")
+	if !found {
+		return "", text
+	}
+	if _, err := time.Parse(time.RFC3339Nano, timestamp); err != nil {
+		return "", text
+	}
+	return timestamp, line
+}
+
+// startSSE sends the headers of a server-sent events stream
+func startSSE(c echo.Context) {
+	c.Response().Header().Set("Content-Type", "text/event-stream")
+	c.Response().Header().Set("Cache-Control", "no-cache")
+	c.Response().Header().Set("Connection", "keep-alive")
+
+	// Flush to ensure the headers are sent
+	c.Response().WriteHeader(http.StatusOK)
+	c.Response().Flush()
+}
+
+type LogTarget struct {
+	Pod       string
+	Container string
+}
+
+// CollectLogs reads the logs of all targets and interleaves them by timestamp.
+// With prefix set each line starts with the name of its pod.
+func CollectLogs(ctx context.Context, clientset *kubernetes.Clientset, ns string, targets []LogTarget, options LogOptions, prefix bool) ([]LogLine, error) {
+	type timedLine struct {
+		time time.Time
+		line LogLine
+	}
+	withTimestamps := options
+	withTimestamps.Timestamps = true
+	withTimestamps.Follow = false
+	if withTimestamps.TailLines == nil {
+		tailLines := int64(DefaultLogTailLines)
+		withTimestamps.TailLines = &tailLines
+	}
+
+	lines := make([]timedLine, 0)
+	for _, target := range targets {
+		err := StreamPodLogs(ctx, clientset, ns, target.Pod, target.Container, withTimestamps, func(line LogLine) error {
+			timestamp, _ := time.Parse(time.RFC3339Nano, line.Timestamp)
+			if !options.Timestamps {
+				line.Timestamp = ""
+			}
+			if prefix {
+				line.Line = line.Pod + " " + line.Line
+			}
+			lines = append(lines, timedLine{time: timestamp, line: line})
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to read logs of %s/%s: %v", target.Pod, target.Container, err)
+		}
+	}
+
+	sort.SliceStable(lines, func(i, j int) bool {
+		return lines[i].time.Before(lines[j].time)
+	})
+	result := make([]LogLine, 0, len(lines))
+	for _, line := range lines {
+		result = append(result, line.line)
+	}
+	return result, nil
+}
+
+// FollowLogs streams the logs of all targets as server-sent events until every stream ends or the client disconnects.
+// Lines are sent in the order they arrive, with prefix set each line starts with the name of its pod.
+func FollowLogs(c echo.Context, clientset *kubernetes.Clientset, ns string, targets []LogTarget, options LogOptions, prefix bool) error {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	lines := make(chan LogLine)
+	done := make(chan error)
+	for _, target := range targets {
+		go func(target LogTarget) {
+			err := StreamPodLogs(ctx, clientset, ns, target.Pod, target.Container, options, func(line LogLine) error {
+				select {
+				case lines <- line:
+					return nil
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			})
+			if err != nil && ctx.Err() == nil {
+				err = fmt.Errorf("unable to follow logs of %s/%s: %v", target.Pod, target.Container, err)
+			} else {
+				err = nil
+			}
+			select {
+			case done <- err:
+			case <-ctx.Done():
+			}
+		}(target)
+	}
+
+	startSSE(c)
+
+	heartbeat := time.NewTicker(WatchHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	running := len(targets)
+	for running > 0 {
+		select {
+		case <-ctx.Done():
+			// Client has disconnected
+			return nil
+		case <-heartbeat.C:
+			if err := writeSSE(c, "heartbeat", "", map[string]int{"streams": running}); err != nil {
+				return nil
+			}
+		case line := <-lines:
+			if prefix {
+				line.Line = line.Pod + " " + line.Line
+			}
+			if err := writeSSE(c, "", "", line); err != nil {
+				return nil
+			}
+		case err := <-done:
+			running--
+			if err != nil {
+				logger.Warnf("%v", err)
+				if writeSSE(c, "error", "", map[string]string{"message": err.Error()}) != nil {
+					return nil
+				}
+			}
+		}
+	}
+
+	_ = writeSSE(c, "end", "", map[string]string{"message": "All log streams ended"})
+	return nil
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	logger "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metricsclientset "k8s.io/metrics/pkg/client/clientset/versioned"
+)
+
+type UsageValue struct {
+	Usage    string `json:"usage"`
+	Requests string `json:"requests"`
+	Limits   string `json:"limits"`
+	// Usage relative to requests and limits, 0 when they are not set
+	RequestsPercent int64 `json:"requests_percent"`
+	LimitsPercent   int64 `json:"limits_percent"`
+	// Usage relative to what a node can allocate, only set for nodes
+	Allocatable        string `json:"allocatable,omitempty"`
+	AllocatablePercent int64  `json:"allocatable_percent,omitempty"`
+}
+
+type ResourceUsage struct {
+	// Available is false when the cluster does not serve metrics.k8s.io or has no sample for the object yet
+	Available bool       `json:"available"`
+	CPU       UsageValue `json:"cpu"`
+	Memory    UsageValue `json:"memory"`
+}
+
+func GetMetricsClientSet(id, name, handler string) (*metricsclientset.Clientset, string, error) {
+	kubeConfig, errMsg, err := GetRestConfig(id, name, handler)
+	if err != nil {
+		return nil, errMsg, err
+	}
+
+	clientset, err := metricsclientset.NewForConfig(kubeConfig)
+	if err != nil {
+		return nil, "Failed to create metrics clientset when calling " + handler, err
+	}
+
+	return clientset, "", nil
+}
+
+// PodUsages returns the current usage of every pod in the namespace summed over its containers.
+// A missing metrics-server is not an error, the second value reports whether metrics could be read.
+func PodUsages(id, name, ns string) (map[string]v1.ResourceList, bool) {
+	metricsClient, errMsg, err := GetMetricsClientSet(id, name, "PodUsages")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return nil, false
+	}
+	podMetrics, err := metricsClient.MetricsV1beta1().PodMetricses(ns).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		logger.Debugf("Pod metrics are unavailable for cluster %s: %v", name, err)
+		return nil, false
+	}
+
+	result := make(map[string]v1.ResourceList)
+	for _, podMetric := range podMetrics.Items {
+		usage := v1.ResourceList{}
+		for _, container := range podMetric.Containers {
+			addResourceList(usage, container.Usage)
+		}
+		result[podMetric.Namespace+"/"+podMetric.Name] = usage
+	}
+	return result, true
+}
+
+// NodeUsages returns the current usage of every node, see PodUsages
+func NodeUsages(id, name string) (map[string]v1.ResourceList, bool) {
+	metricsClient, errMsg, err := GetMetricsClientSet(id, name, "NodeUsages")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return nil, false
+	}
+	nodeMetrics, err := metricsClient.MetricsV1beta1().NodeMetricses().List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		logger.Debugf("Node metrics are unavailable for cluster %s: %v", name, err)
+		return nil, false
+	}
+
+	result := make(map[string]v1.ResourceList)
+	for _, nodeMetric := range nodeMetrics.Items {
+		result[nodeMetric.Name] = nodeMetric.Usage
+	}
+	return result, true
+}
+
+// NewResourceUsage compares usage with requests and limits, allocatable is only given for nodes
+func NewResourceUsage(usage, requests, limits, allocatable v1.ResourceList) ResourceUsage {
+	if usage == nil {
+		return ResourceUsage{}
+	}
+	return ResourceUsage{
+		Available: true,
+		CPU:       newUsageValue(v1.ResourceCPU, usage, requests, limits, allocatable),
+		Memory:    newUsageValue(v1.ResourceMemory, usage, requests, limits, allocatable),
+	}
+}
+
+func newUsageValue(name v1.ResourceName, usage, requests, limits, allocatable v1.ResourceList) UsageValue {
+	value := UsageValue{
+		Usage:    quantityString(usage, name),
+		Requests: quantityString(requests, name),
+		Limits:   quantityString(limits, name),
+	}
+	usageQuantity := usage[name]
+	value.RequestsPercent = percentOf(usageQuantity.MilliValue(), requests, name)
+	value.LimitsPercent = percentOf(usageQuantity.MilliValue(), limits, name)
+	if allocatable != nil {
+		value.Allocatable = quantityString(allocatable, name)
+		value.AllocatablePercent = percentOf(usageQuantity.MilliValue(), allocatable, name)
+	}
+	return value
+}
+
+func percentOf(milliValue int64, list v1.ResourceList, name v1.ResourceName) int64 {
+	if quantity, ok := list[name]; ok && quantity.MilliValue() > 0 {
+		return milliValue * 100 / quantity.MilliValue()
+	}
+	return 0
+}
+
+// WorkloadUsage sums the usage, requests and limits of the running pods of a workload
+func WorkloadUsage(id, name, ns string, pods []v1.Pod) ResourceUsage {
+	usages, ok := PodUsages(id, name, ns)
+	if !ok {
+		return ResourceUsage{}
+	}
+	usage, requests, limits := v1.ResourceList{}, v1.ResourceList{}, v1.ResourceList{}
+	for _, pod := range pods {
+		podUsage, ok := usages[pod.Namespace+"/"+pod.Name]
+		if !ok {
+			continue
+		}
+		podRequests, podLimits := PodRequestsAndLimits(pod)
+		addResourceList(usage, podUsage)
+		addResourceList(requests, podRequests)
+		addResourceList(limits, podLimits)
+	}
+	return NewResourceUsage(usage, requests, limits, nil)
+}
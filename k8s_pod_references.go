@@ -0,0 +1,105 @@
+package main
+
+import (
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+type PodReferences struct {
+	ConfigMaps             map[string]bool
+	Secrets                map[string]bool
+	PersistentVolumeClaims map[string]bool
+}
+
+// NewPodReferences collects the names of ConfigMaps, Secrets and PersistentVolumeClaims a pod spec refers to
+// through volumes, projected volumes, environment and image pull secrets.
+func NewPodReferences(pod v1.Pod) PodReferences {
+	refs := PodReferences{
+		ConfigMaps:             make(map[string]bool),
+		Secrets:                make(map[string]bool),
+		PersistentVolumeClaims: make(map[string]bool),
+	}
+
+	for _, volume := range pod.Spec.Volumes {
+		if volume.ConfigMap != nil {
+			refs.ConfigMaps[volume.ConfigMap.Name] = true
+		}
+		if volume.Secret != nil {
+			refs.Secrets[volume.Secret.SecretName] = true
+		}
+		if volume.PersistentVolumeClaim != nil {
+			refs.PersistentVolumeClaims[volume.PersistentVolumeClaim.ClaimName] = true
+		}
+		if volume.Ephemeral != nil {
+			// Generic ephemeral volumes create a claim named after the pod and the volume
+			refs.PersistentVolumeClaims[pod.Name+"-"+volume.Name] = true
+		}
+		if volume.Projected != nil {
+			for _, source := range volume.Projected.Sources {
+				if source.ConfigMap != nil {
+					refs.ConfigMaps[source.ConfigMap.Name] = true
+				}
+				if source.Secret != nil {
+					refs.Secrets[source.Secret.Name] = true
+				}
+			}
+		}
+	}
+
+	containers := append([]v1.Container{}, pod.Spec.InitContainers...)
+	containers = append(containers, pod.Spec.Containers...)
+	for _, container := range pod.Spec.EphemeralContainers {
+		containers = append(containers, v1.Container(container.EphemeralContainerCommon))
+	}
+	for _, container := range containers {
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.ConfigMapRef != nil {
+				refs.ConfigMaps[envFrom.ConfigMapRef.Name] = true
+			}
+			if envFrom.SecretRef != nil {
+				refs.Secrets[envFrom.SecretRef.Name] = true
+			}
+		}
+		for _, env := range container.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+			if env.ValueFrom.ConfigMapKeyRef != nil {
+				refs.ConfigMaps[env.ValueFrom.ConfigMapKeyRef.Name] = true
+			}
+			if env.ValueFrom.SecretKeyRef != nil {
+				refs.Secrets[env.ValueFrom.SecretKeyRef.Name] = true
+			}
+		}
+	}
+
+	for _, pullSecret := range pod.Spec.ImagePullSecrets {
+		refs.Secrets[pullSecret.Name] = true
+	}
+
+	return refs
+}
+
+// PodsUsing returns the sorted names of pods that refer to the resource of the given kind.
+// Supported kinds are ConfigMap, Secret and PersistentVolumeClaim.
+func PodsUsing(pods []v1.Pod, kind, name string) []string {
+	result := make([]string, 0)
+	for _, pod := range pods {
+		refs := NewPodReferences(pod)
+		var used bool
+		switch kind {
+		case "ConfigMap":
+			used = refs.ConfigMaps[name]
+		case "Secret":
+			used = refs.Secrets[name]
+		case "PersistentVolumeClaim":
+			used = refs.PersistentVolumeClaims[name]
+		}
+		if used {
+			result = append(result, pod.Name)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
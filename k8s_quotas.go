@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	logger "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sort"
+	"sync"
+	"time"
+)
+
+// QuotaAlertPercent is the share of a hard quota above which a namespace is reported in the activity console
+const QuotaAlertPercent = 80
+
+type QuotaResource struct {
+	Resource string `json:"resource"`
+	Used     string `json:"used"`
+	Hard     string `json:"hard"`
+	Percent  int64  `json:"percent"`
+}
+
+// NewQuotaResources compares used with hard for every resource of a quota, sorted by resource name
+func NewQuotaResources(quota v1.ResourceQuota) []QuotaResource {
+	result := make([]QuotaResource, 0)
+	for name, hard := range quota.Status.Hard {
+		used := quota.Status.Used[name]
+		resource := QuotaResource{
+			Resource: string(name),
+			Used:     used.String(),
+			Hard:     hard.String(),
+		}
+		// Milli values of large storage quotas overflow int64, so the ratio is computed on floats
+		if hard.Sign() > 0 {
+			resource.Percent = int64(used.AsApproximateFloat64() * 100 / hard.AsApproximateFloat64())
+		} else if used.Sign() > 0 {
+			// Nothing is allowed and something is used
+			resource.Percent = 100
+		}
+		result = append(result, resource)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Resource < result[j].Resource
+	})
+	return result
+}
+
+// QuotaWatcher reports quotas above QuotaAlertPercent in the activity console.
+// A quota is reported again only after it went back below the threshold.
+type QuotaWatcher struct {
+	interval time.Duration
+	mu       sync.Mutex
+	alerted  map[string]bool
+}
+
+func StartQuotaWatcher(config QuotaConfig) {
+	if !config.Enabled {
+		return
+	}
+	w := &QuotaWatcher{
+		interval: time.Duration(config.IntervalMinutes) * time.Minute,
+		alerted:  make(map[string]bool),
+	}
+	go w.run()
+}
+
+func (w *QuotaWatcher) run() {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for ; true; <-ticker.C {
+		clusters, err := ListClusters()
+		if err != nil {
+			logger.Warnf("Failed to list clusters for quota checks: %v", err)
+			continue
+		}
+		for _, cluster := range clusters {
+			if err := w.check(cluster); err != nil {
+				logger.Debugf("Failed to check quotas of cluster %s: %v", cluster.Name, err)
+			}
+		}
+	}
+}
+
+func (w *QuotaWatcher) check(cluster ClusterRef) error {
+	clientset, _, err := GetClientSet(cluster.ID, cluster.Name, "QuotaWatcher")
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), w.interval)
+	defer cancel()
+	quotas, err := clientset.CoreV1().ResourceQuotas(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, quota := range quotas.Items {
+		for _, resource := range NewQuotaResources(quota) {
+			key := cluster.ID + "/" + cluster.Name + "/" + quota.Namespace + "/" + quota.Name + "/" + resource.Resource
+			if resource.Percent < QuotaAlertPercent {
+				delete(w.alerted, key)
+				continue
+			}
+			if w.alerted[key] {
+				continue
+			}
+			w.alerted[key] = true
+			LogActivityConsoleAdd(fmt.Sprintf("Namespace %s in cluster %s uses %d%% of %s in quota %s (%s of %s)",
+				quota.Namespace, cluster.Name, resource.Percent, resource.Resource, quota.Name, resource.Used, resource.Hard), "Quota")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,311 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sort"
+	"strings"
+)
+
+// RBACSubject is a user, group or service account that bindings grant roles to
+type RBACSubject struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+func (s RBACSubject) String() string {
+	if s.Kind == rbacv1.ServiceAccountKind {
+		return s.Kind + ":" + s.Namespace + "/" + s.Name
+	}
+	return s.Kind + ":" + s.Name
+}
+
+// RBACGrant tells through which binding and role a subject got a rule
+type RBACGrant struct {
+	Subject     RBACSubject `json:"subject"`
+	BindingKind string      `json:"binding_kind"`
+	Binding     string      `json:"binding"`
+	Namespace   string      `json:"namespace"`
+	RoleKind    string      `json:"role_kind"`
+	Role        string      `json:"role"`
+}
+
+type EffectiveRule struct {
+	Namespace       string   `json:"namespace"`
+	Verbs           []string `json:"verbs"`
+	APIGroups       []string `json:"api_groups"`
+	Resources       []string `json:"resources"`
+	ResourceNames   []string `json:"resource_names"`
+	NonResourceURLs []string `json:"non_resource_urls"`
+	BindingKind     string   `json:"binding_kind"`
+	Binding         string   `json:"binding"`
+	RoleKind        string   `json:"role_kind"`
+	Role            string   `json:"role"`
+}
+
+// RBACObjects holds the roles and bindings needed to evaluate access without calling the API server per binding
+type RBACObjects struct {
+	Roles               []rbacv1.Role
+	ClusterRoles        []rbacv1.ClusterRole
+	RoleBindings        []rbacv1.RoleBinding
+	ClusterRoleBindings []rbacv1.ClusterRoleBinding
+}
+
+// LoadRBAC lists the roles and role bindings of ns (all namespaces when empty) and every cluster role and binding
+func LoadRBAC(clientset *kubernetes.Clientset, ns string) (*RBACObjects, error) {
+	ctx := context.Background()
+	roles, err := clientset.RbacV1().Roles(ns).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list roles: %v", err)
+	}
+	clusterRoles, err := clientset.RbacV1().ClusterRoles().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list cluster roles: %v", err)
+	}
+	roleBindings, err := clientset.RbacV1().RoleBindings(ns).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list role bindings: %v", err)
+	}
+	clusterRoleBindings, err := clientset.RbacV1().ClusterRoleBindings().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list cluster role bindings: %v", err)
+	}
+	return &RBACObjects{
+		Roles:               roles.Items,
+		ClusterRoles:        clusterRoles.Items,
+		RoleBindings:        roleBindings.Items,
+		ClusterRoleBindings: clusterRoleBindings.Items,
+	}, nil
+}
+
+// RoleRules resolves the role a binding refers to, role bindings may refer to a Role of their namespace or to a ClusterRole
+func (o *RBACObjects) RoleRules(ns string, ref rbacv1.RoleRef) ([]rbacv1.PolicyRule, bool) {
+	switch ref.Kind {
+	case "Role":
+		for _, role := range o.Roles {
+			if role.Namespace == ns && role.Name == ref.Name {
+				return role.Rules, true
+			}
+		}
+	case "ClusterRole":
+		for _, role := range o.ClusterRoles {
+			if role.Name == ref.Name {
+				return role.Rules, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// WhoCan walks every binding and returns the subjects whose roles allow the verb on the resource in ns.
+// An empty ns asks about cluster-wide access, which only cluster role bindings grant.
+func (o *RBACObjects) WhoCan(verb, group, resource, ns string) []RBACGrant {
+	result := make([]RBACGrant, 0)
+	for _, binding := range o.ClusterRoleBindings {
+		rules, ok := o.RoleRules("", binding.RoleRef)
+		if !ok || !RulesAllow(rules, verb, group, resource) {
+			continue
+		}
+		for _, subject := range binding.Subjects {
+			result = append(result, RBACGrant{
+				Subject:     NewRBACSubject(subject, ""),
+				BindingKind: "ClusterRoleBinding",
+				Binding:     binding.Name,
+				RoleKind:    binding.RoleRef.Kind,
+				Role:        binding.RoleRef.Name,
+			})
+		}
+	}
+	if ns == "" {
+		return result
+	}
+	for _, binding := range o.RoleBindings {
+		if binding.Namespace != ns {
+			continue
+		}
+		rules, ok := o.RoleRules(binding.Namespace, binding.RoleRef)
+		if !ok || !RulesAllow(rules, verb, group, resource) {
+			continue
+		}
+		for _, subject := range binding.Subjects {
+			result = append(result, RBACGrant{
+				Subject:     NewRBACSubject(subject, binding.Namespace),
+				BindingKind: "RoleBinding",
+				Binding:     binding.Name,
+				Namespace:   binding.Namespace,
+				RoleKind:    binding.RoleRef.Kind,
+				Role:        binding.RoleRef.Name,
+			})
+		}
+	}
+	return result
+}
+
+// EffectiveRules collects the rules granted to a subject by all bindings, including those granted through
+// the groups Kubernetes implicitly adds to authenticated users and service accounts
+func (o *RBACObjects) EffectiveRules(subject RBACSubject) []EffectiveRule {
+	result := make([]EffectiveRule, 0)
+	add := func(ns, bindingKind, binding string, ref rbacv1.RoleRef) {
+		rules, ok := o.RoleRules(ns, ref)
+		if !ok {
+			return
+		}
+		for _, rule := range rules {
+			result = append(result, EffectiveRule{
+				Namespace:       ns,
+				Verbs:           rule.Verbs,
+				APIGroups:       rule.APIGroups,
+				Resources:       rule.Resources,
+				ResourceNames:   rule.ResourceNames,
+				NonResourceURLs: rule.NonResourceURLs,
+				BindingKind:     bindingKind,
+				Binding:         binding,
+				RoleKind:        ref.Kind,
+				Role:            ref.Name,
+			})
+		}
+	}
+
+	for _, binding := range o.ClusterRoleBindings {
+		if SubjectsInclude(binding.Subjects, "", subject) {
+			add("", "ClusterRoleBinding", binding.Name, binding.RoleRef)
+		}
+	}
+	for _, binding := range o.RoleBindings {
+		if SubjectsInclude(binding.Subjects, binding.Namespace, subject) {
+			add(binding.Namespace, "RoleBinding", binding.Name, binding.RoleRef)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Namespace < result[j].Namespace
+	})
+	return result
+}
+
+// NewRBACSubject fills in the namespace of service accounts which bindings may leave empty
+func NewRBACSubject(subject rbacv1.Subject, bindingNamespace string) RBACSubject {
+	ns := subject.Namespace
+	if subject.Kind == rbacv1.ServiceAccountKind && ns == "" {
+		ns = bindingNamespace
+	}
+	return RBACSubject{
+		Kind:      subject.Kind,
+		Name:      subject.Name,
+		Namespace: ns,
+	}
+}
+
+// SubjectsInclude reports whether any subject of a binding applies to the given subject
+func SubjectsInclude(subjects []rbacv1.Subject, bindingNamespace string, subject RBACSubject) bool {
+	groups := implicitGroups(subject)
+	for _, s := range subjects {
+		bound := NewRBACSubject(s, bindingNamespace)
+		if bound.Kind == subject.Kind && bound.Name == subject.Name &&
+			(subject.Kind != rbacv1.ServiceAccountKind || bound.Namespace == subject.Namespace) {
+			return true
+		}
+		if bound.Kind == rbacv1.GroupKind {
+			for _, group := range groups {
+				if bound.Name == group {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func implicitGroups(subject RBACSubject) []string {
+	switch subject.Kind {
+	case rbacv1.ServiceAccountKind:
+		return []string{"system:authenticated", "system:serviceaccounts", "system:serviceaccounts:" + subject.Namespace}
+	case rbacv1.UserKind:
+		if subject.Name == "system:anonymous" {
+			return []string{"system:unauthenticated"}
+		}
+		return []string{"system:authenticated"}
+	}
+	return nil
+}
+
+// RulesAllow matches a request against rules the way the RBAC authorizer does, resource may carry a subresource like pods/log
+func RulesAllow(rules []rbacv1.PolicyRule, verb, group, resource string) bool {
+	for _, rule := range rules {
+		if len(rule.ResourceNames) > 0 {
+			// Only grants access to specific objects, not to the resource in general
+			continue
+		}
+		if ruleMatches(rule.Verbs, verb) && ruleMatches(rule.APIGroups, group) && resourceMatches(rule.Resources, resource) {
+			return true
+		}
+	}
+	return false
+}
+
+func ruleMatches(values []string, value string) bool {
+	for _, v := range values {
+		if v == rbacv1.VerbAll || v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func resourceMatches(resources []string, resource string) bool {
+	for _, r := range resources {
+		if r == rbacv1.ResourceAll || r == resource {
+			return true
+		}
+		// "*/scale" matches the scale subresource of every resource
+		if strings.HasPrefix(r, "*/") {
+			if i := strings.Index(resource, "/"); i >= 0 && r[1:] == resource[i:] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// FormatPolicyRule describes a rule like kubectl describe role does, one line per resource, e.g. "deployments.apps [get list]"
+func FormatPolicyRule(rule rbacv1.PolicyRule) []string {
+	result := make([]string, 0)
+	verbs := "[" + strings.Join(rule.Verbs, " ") + "]"
+	names := ""
+	if len(rule.ResourceNames) > 0 {
+		names = " [" + strings.Join(rule.ResourceNames, " ") + "]"
+	}
+	for _, resource := range rule.Resources {
+		for _, group := range rule.APIGroups {
+			name := resource
+			if group != "" {
+				name += "." + group
+			}
+			result = append(result, name+names+" "+verbs)
+		}
+	}
+	for _, url := range rule.NonResourceURLs {
+		result = append(result, url+" "+verbs)
+	}
+	return result
+}
+
+func FormatPolicyRules(rules []rbacv1.PolicyRule) []string {
+	result := make([]string, 0)
+	for _, rule := range rules {
+		result = append(result, FormatPolicyRule(rule)...)
+	}
+	return result
+}
+
+func FormatSubjects(subjects []rbacv1.Subject, bindingNamespace string) []string {
+	result := make([]string, 0)
+	for _, subject := range subjects {
+		result = append(result, NewRBACSubject(subject, bindingNamespace).String())
+	}
+	return result
+}
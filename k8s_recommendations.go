@@ -0,0 +1,279 @@
+package main
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sort"
+	"time"
+)
+
+const (
+	RecommendationOK               = "ok"
+	RecommendationOverProvisioned  = "over-provisioned"
+	RecommendationUnderProvisioned = "under-provisioned"
+	RecommendationInsufficientData = "insufficient-data"
+	recommendationBucket           = 5 * time.Minute
+	// Containers need this many 5 minute averages, counted over all their pods, before anything is suggested
+	recommendationMinBuckets        = 24
+	recommendationRequestHeadroom   = 1.15
+	recommendationLimitHeadroom     = 1.25
+	recommendationOverProvisionedAt = 2.0
+)
+
+// recommendationMinimums keep idle containers from getting requests that are too small to schedule sensibly
+var recommendationMinimums = map[v1.ResourceName]int64{
+	v1.ResourceCPU:    10,
+	v1.ResourceMemory: 16 * 1024 * 1024,
+}
+
+type UsageStats struct {
+	P50     int64 `json:"p50"`
+	P95     int64 `json:"p95"`
+	Max     int64 `json:"max"`
+	Samples int   `json:"samples"`
+}
+
+type ResourceRecommendation struct {
+	Request          string `json:"request"`
+	Limit            string `json:"limit"`
+	P50              string `json:"p50"`
+	P95              string `json:"p95"`
+	Max              string `json:"max"`
+	SuggestedRequest string `json:"suggested_request"`
+	SuggestedLimit   string `json:"suggested_limit"`
+	// Savings is what all replicas would free by using the suggested request, negative when more is needed
+	Savings      string `json:"savings"`
+	SavingsValue int64  `json:"-"`
+}
+
+type ContainerRecommendation struct {
+	Kind      string                 `json:"kind"`
+	Workload  string                 `json:"workload"`
+	Container string                 `json:"container"`
+	Replicas  int32                  `json:"replicas"`
+	Status    string                 `json:"status"`
+	Reasons   []string               `json:"reasons"`
+	CPU       ResourceRecommendation `json:"cpu"`
+	Memory    ResourceRecommendation `json:"memory"`
+}
+
+type containerUsageKey struct {
+	OwnerKind string
+	OwnerName string
+	Container string
+}
+
+type containerUsage struct {
+	CPU    UsageStats
+	Memory UsageStats
+}
+
+// ContainerUsageStats returns the p50, p95 and max usage of every container of the workloads in a namespace since the given time.
+// Percentiles are taken over 5 minute averages, the max over the raw samples.
+func ContainerUsageStats(clusterID, cluster, ns string, since time.Time) (map[containerUsageKey]containerUsage, error) {
+	type bucket struct {
+		ID struct {
+			OwnerKind string `bson:"owner_kind"`
+			OwnerName string `bson:"owner_name"`
+			Container string `bson:"container"`
+		} `bson:"_id"`
+		CPU       []float64 `bson:"cpu"`
+		Memory    []float64 `bson:"memory"`
+		MaxCPU    int64     `bson:"max_cpu"`
+		MaxMemory int64     `bson:"max_memory"`
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"meta.cluster_id": clusterID,
+			"meta.cluster":    cluster,
+			"meta.kind":       "Container",
+			"meta.namespace":  ns,
+			"meta.owner_kind": bson.M{"$ne": ""},
+			"time":            bson.M{"$gte": since},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{
+				"owner_kind": "$meta.owner_kind",
+				"owner_name": "$meta.owner_name",
+				"container":  "$meta.container",
+				"pod":        "$meta.name",
+				"time":       bson.M{"$dateTrunc": bson.M{"date": "$time", "unit": "minute", "binSize": int(recommendationBucket.Minutes())}},
+			},
+			"cpu":        bson.M{"$avg": "$cpu"},
+			"memory":     bson.M{"$avg": "$memory"},
+			"max_cpu":    bson.M{"$max": "$cpu"},
+			"max_memory": bson.M{"$max": "$memory"},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{
+				"owner_kind": "$_id.owner_kind",
+				"owner_name": "$_id.owner_name",
+				"container":  "$_id.container",
+			},
+			"cpu":        bson.M{"$push": "$cpu"},
+			"memory":     bson.M{"$push": "$memory"},
+			"max_cpu":    bson.M{"$max": "$max_cpu"},
+			"max_memory": bson.M{"$max": "$max_memory"},
+		}}},
+	}
+
+	buckets := make([]bucket, 0)
+	if err := DBHelper.Aggregate(UsageSamples, pipeline, &buckets); err != nil {
+		return nil, err
+	}
+
+	result := make(map[containerUsageKey]containerUsage)
+	for _, b := range buckets {
+		result[containerUsageKey{OwnerKind: b.ID.OwnerKind, OwnerName: b.ID.OwnerName, Container: b.ID.Container}] = containerUsage{
+			CPU:    newUsageStats(b.CPU, b.MaxCPU),
+			Memory: newUsageStats(b.Memory, b.MaxMemory),
+		}
+	}
+	return result, nil
+}
+
+func newUsageStats(values []float64, max int64) UsageStats {
+	sort.Float64s(values)
+	return UsageStats{
+		P50:     int64(percentile(values, 0.50)),
+		P95:     int64(percentile(values, 0.95)),
+		Max:     max,
+		Samples: len(values),
+	}
+}
+
+// percentile of sorted values by nearest rank
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(p*float64(len(sorted))+0.5) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return sorted[rank]
+}
+
+// RecommendWorkload compares the requests and limits of each container of a pod template with its observed usage
+func RecommendWorkload(kind, name string, replicas int32, spec v1.PodSpec, usages map[containerUsageKey]containerUsage) []ContainerRecommendation {
+	result := make([]ContainerRecommendation, 0)
+	for _, container := range spec.Containers {
+		recommendation := ContainerRecommendation{
+			Kind:      kind,
+			Workload:  name,
+			Container: container.Name,
+			Replicas:  replicas,
+			Status:    RecommendationOK,
+			Reasons:   make([]string, 0),
+		}
+		usage, ok := usages[containerUsageKey{OwnerKind: kind, OwnerName: name, Container: container.Name}]
+		if !ok || usage.CPU.Samples < recommendationMinBuckets {
+			recommendation.Status = RecommendationInsufficientData
+			recommendation.CPU = currentResources(v1.ResourceCPU, container.Resources)
+			recommendation.Memory = currentResources(v1.ResourceMemory, container.Resources)
+			result = append(result, recommendation)
+			continue
+		}
+
+		var reasons []string
+		var over, under bool
+		recommendation.CPU, reasons, over, under = recommendResource(v1.ResourceCPU, container.Resources, usage.CPU, replicas)
+		recommendation.Reasons = append(recommendation.Reasons, reasons...)
+		memory, memoryReasons, memoryOver, memoryUnder := recommendResource(v1.ResourceMemory, container.Resources, usage.Memory, replicas)
+		recommendation.Memory = memory
+		recommendation.Reasons = append(recommendation.Reasons, memoryReasons...)
+
+		switch {
+		case under || memoryUnder:
+			recommendation.Status = RecommendationUnderProvisioned
+		case over || memoryOver:
+			recommendation.Status = RecommendationOverProvisioned
+		}
+		result = append(result, recommendation)
+	}
+	return result
+}
+
+func currentResources(name v1.ResourceName, resources v1.ResourceRequirements) ResourceRecommendation {
+	return ResourceRecommendation{
+		Request: quantityString(resources.Requests, name),
+		Limit:   quantityString(resources.Limits, name),
+	}
+}
+
+func recommendResource(name v1.ResourceName, resources v1.ResourceRequirements, stats UsageStats, replicas int32) (ResourceRecommendation, []string, bool, bool) {
+	recommendation := currentResources(name, resources)
+	recommendation.P50 = usageQuantity(name, stats.P50).String()
+	recommendation.P95 = usageQuantity(name, stats.P95).String()
+	recommendation.Max = usageQuantity(name, stats.Max).String()
+
+	suggestedRequest := int64(float64(stats.P95) * recommendationRequestHeadroom)
+	if minimum := recommendationMinimums[name]; suggestedRequest < minimum {
+		suggestedRequest = minimum
+	}
+	suggestedLimit := int64(float64(stats.Max) * recommendationLimitHeadroom)
+	if suggestedLimit < suggestedRequest {
+		suggestedLimit = suggestedRequest
+	}
+	recommendation.SuggestedRequest = usageQuantity(name, suggestedRequest).String()
+	// CPU limits throttle rather than kill, only suggest one when the container already has one
+	if _, hasLimit := resources.Limits[name]; hasLimit || name == v1.ResourceMemory {
+		recommendation.SuggestedLimit = usageQuantity(name, suggestedLimit).String()
+	}
+
+	var reasons []string
+	var over, under bool
+	request, hasRequest := resources.Requests[name]
+	current := usageValue(name, request)
+	switch {
+	case !hasRequest:
+		under = true
+		reasons = append(reasons, "no "+string(name)+" request")
+	case stats.P95 > current:
+		under = true
+		reasons = append(reasons, string(name)+" p95 usage "+recommendation.P95+" is above the request "+recommendation.Request)
+	case float64(current) > float64(suggestedRequest)*recommendationOverProvisionedAt:
+		over = true
+		reasons = append(reasons, string(name)+" request "+recommendation.Request+" is more than twice the suggested "+recommendation.SuggestedRequest)
+	}
+	if limit, hasLimit := resources.Limits[name]; hasLimit && float64(stats.Max) > float64(usageValue(name, limit))*0.9 {
+		under = true
+		if name == v1.ResourceMemory {
+			reasons = append(reasons, "memory max usage "+recommendation.Max+" is close to the limit "+recommendation.Limit+", risk of OOM kills")
+		} else {
+			reasons = append(reasons, "cpu max usage "+recommendation.Max+" is close to the limit "+recommendation.Limit+", risk of throttling")
+		}
+	}
+
+	recommendation.SavingsValue = (current - suggestedRequest) * int64(replicas)
+	recommendation.Savings = usageQuantity(name, recommendation.SavingsValue).String()
+	return recommendation, reasons, over, under
+}
+
+// usageValue is the unit samples are stored in: millicores for cpu and bytes for memory
+func usageValue(name v1.ResourceName, quantity resource.Quantity) int64 {
+	if name == v1.ResourceCPU {
+		return quantity.MilliValue()
+	}
+	return quantity.Value()
+}
+
+// usageQuantity formats a value in the unit of samples, memory is rounded up to whole MiB to stay readable
+func usageQuantity(name v1.ResourceName, value int64) *resource.Quantity {
+	if name == v1.ResourceCPU {
+		return resource.NewMilliQuantity(value, resource.DecimalSI)
+	}
+	const mebibyte = 1024 * 1024
+	if value > 0 {
+		value = (value + mebibyte - 1) / mebibyte * mebibyte
+	} else {
+		value = value / mebibyte * mebibyte
+	}
+	return resource.NewQuantity(value, resource.BinarySI)
+}
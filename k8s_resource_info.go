@@ -0,0 +1,78 @@
+package main
+
+import (
+	"encoding/json"
+	"gopkg.in/yaml.v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type ResourceMetadataCustom struct {
+	Name      string `json:"name"`
+	Operation string `json:"operation"`
+	Updated   string `json:"updated"`
+	Fields    string `json:"fields"`
+}
+
+type ResourceMetadata struct {
+	Age         string                   `json:"age"`
+	Labels      []string                 `json:"labels"`
+	Annotations []string                 `json:"annotations"`
+	Custom      []ResourceMetadataCustom `json:"custom"`
+}
+
+func NewResourceMetadata(obj metav1.Object) ResourceMetadata {
+	metadata := ResourceMetadata{
+		Age:         ElapsedTimeShort(obj.GetCreationTimestamp().Time),
+		Labels:      FormatKeyValues(obj.GetLabels()),
+		Annotations: FormatKeyValues(obj.GetAnnotations()),
+		Custom:      make([]ResourceMetadataCustom, 0),
+	}
+
+	for _, mField := range obj.GetManagedFields() {
+		fieldsJson, err := json.Marshal(mField.FieldsV1)
+		if err != nil {
+			continue
+		}
+		metadata.Custom = append(metadata.Custom, ResourceMetadataCustom{
+			Name:      mField.Manager,
+			Operation: string(mField.Operation),
+			Updated:   ElapsedTimeShort(mField.Time.Time),
+			Fields:    string(fieldsJson),
+		})
+	}
+
+	return metadata
+}
+
+// ResourceYAML renders a resource the way kubectl shows it: with apiVersion and kind set and without managed fields.
+func ResourceYAML(obj interface{}, apiVersion, kind string) (string, error) {
+	d, err := json.Marshal(obj)
+	if err != nil {
+		return "", err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(d, &m); err != nil {
+		return "", err
+	}
+
+	nestedMap, ok := m["metadata"].(map[string]interface{})
+	if ok {
+		delete(nestedMap, "managedFields")
+	}
+	m["apiVersion"] = apiVersion
+	m["kind"] = kind
+
+	yamlStr, err := yaml.Marshal(&m)
+	if err != nil {
+		return "", err
+	}
+	return string(yamlStr), nil
+}
+
+func FormatKeyValues(values map[string]string) []string {
+	result := make([]string, 0)
+	for key, value := range values {
+		result = append(result, key+":"+value)
+	}
+	return result
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+type ResourceAllocation struct {
+	Capacity        string `json:"capacity"`
+	Allocatable     string `json:"allocatable"`
+	Requests        string `json:"requests"`
+	Limits          string `json:"limits"`
+	RequestsPercent int64  `json:"requests_percent"`
+	LimitsPercent   int64  `json:"limits_percent"`
+}
+
+// PodRequestsAndLimits returns the effective requests and limits of a pod the way the scheduler computes them:
+// the sum over app containers or the largest init container, whichever is bigger, plus the pod overhead.
+func PodRequestsAndLimits(pod v1.Pod) (requests v1.ResourceList, limits v1.ResourceList) {
+	requests, limits = v1.ResourceList{}, v1.ResourceList{}
+	for _, container := range pod.Spec.Containers {
+		addResourceList(requests, container.Resources.Requests)
+		addResourceList(limits, container.Resources.Limits)
+	}
+	for _, container := range pod.Spec.InitContainers {
+		maxResourceList(requests, container.Resources.Requests)
+		maxResourceList(limits, container.Resources.Limits)
+	}
+	if pod.Spec.Overhead != nil {
+		addResourceList(requests, pod.Spec.Overhead)
+		for name, quantity := range pod.Spec.Overhead {
+			if value, ok := limits[name]; ok {
+				value.Add(quantity)
+				limits[name] = value
+			}
+		}
+	}
+	return
+}
+
+// NewResourceAllocation compares what pods request and limit against the capacity of a node
+func NewResourceAllocation(name v1.ResourceName, capacity, allocatable, requests, limits v1.ResourceList) ResourceAllocation {
+	allocation := ResourceAllocation{
+		Capacity:    quantityString(capacity, name),
+		Allocatable: quantityString(allocatable, name),
+		Requests:    quantityString(requests, name),
+		Limits:      quantityString(limits, name),
+	}
+	if allocatableQuantity, ok := allocatable[name]; ok && allocatableQuantity.MilliValue() > 0 {
+		if requestsQuantity, ok := requests[name]; ok {
+			allocation.RequestsPercent = requestsQuantity.MilliValue() * 100 / allocatableQuantity.MilliValue()
+		}
+		if limitsQuantity, ok := limits[name]; ok {
+			allocation.LimitsPercent = limitsQuantity.MilliValue() * 100 / allocatableQuantity.MilliValue()
+		}
+	}
+	return allocation
+}
+
+func addResourceList(list, toAdd v1.ResourceList) {
+	for name, quantity := range toAdd {
+		if value, ok := list[name]; ok {
+			value.Add(quantity)
+			list[name] = value
+		} else {
+			list[name] = quantity.DeepCopy()
+		}
+	}
+}
+
+func maxResourceList(list, other v1.ResourceList) {
+	for name, quantity := range other {
+		if value, ok := list[name]; ok {
+			if quantity.Cmp(value) > 0 {
+				list[name] = quantity.DeepCopy()
+			}
+		} else {
+			list[name] = quantity.DeepCopy()
+		}
+	}
+}
+
+func quantityString(list v1.ResourceList, name v1.ResourceName) string {
+	if quantity, ok := list[name]; ok {
+		return quantity.String()
+	}
+	return "0"
+}
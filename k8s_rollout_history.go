@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	v1apps "k8s.io/api/apps/v1"
+	v1core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	RevisionAnnotation    = "deployment.kubernetes.io/revision"
+	ChangeCauseAnnotation = "kubernetes.io/change-cause"
+	PodTemplateHashLabel  = "pod-template-hash"
+)
+
+type DeploymentRevision struct {
+	Revision          int64    `json:"revision"`
+	ReplicaSet        string   `json:"replica_set"`
+	Images            []string `json:"images"`
+	ChangeCause       string   `json:"change_cause"`
+	Created           string   `json:"created"`
+	Age               string   `json:"age"`
+	Replicas          int32    `json:"replicas"`
+	ReadyReplicas     int32    `json:"ready_replicas"`
+	AvailableReplicas int32    `json:"available_replicas"`
+	Current           bool     `json:"current"`
+}
+
+// DeploymentReplicaSets lists the ReplicaSets controlled by a deployment, newest revision first
+func DeploymentReplicaSets(clientset *kubernetes.Clientset, deployment *v1apps.Deployment) ([]v1apps.ReplicaSet, error) {
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+	replicaSets, err := clientset.AppsV1().ReplicaSets(deployment.Namespace).List(context.Background(), metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]v1apps.ReplicaSet, 0)
+	for i := range replicaSets.Items {
+		if metav1.IsControlledBy(&replicaSets.Items[i], deployment) {
+			result = append(result, replicaSets.Items[i])
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return ReplicaSetRevision(result[i]) > ReplicaSetRevision(result[j])
+	})
+	return result, nil
+}
+
+// ReplicaSetRevision reads the revision the deployment controller assigned to a ReplicaSet, 0 when it has none
+func ReplicaSetRevision(rs v1apps.ReplicaSet) int64 {
+	revision, err := strconv.ParseInt(rs.Annotations[RevisionAnnotation], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return revision
+}
+
+func NewDeploymentRevision(rs v1apps.ReplicaSet, currentRevision int64) DeploymentRevision {
+	revision := DeploymentRevision{
+		Revision:          ReplicaSetRevision(rs),
+		ReplicaSet:        rs.Name,
+		Images:            make([]string, 0),
+		ChangeCause:       rs.Annotations[ChangeCauseAnnotation],
+		Created:           rs.CreationTimestamp.Format(time.RFC3339),
+		Age:               ElapsedTimeShort(rs.CreationTimestamp.Time),
+		Replicas:          rs.Status.Replicas,
+		ReadyReplicas:     rs.Status.ReadyReplicas,
+		AvailableReplicas: rs.Status.AvailableReplicas,
+	}
+	revision.Current = revision.Revision == currentRevision
+	for _, container := range rs.Spec.Template.Spec.Containers {
+		revision.Images = append(revision.Images, container.Image)
+	}
+	return revision
+}
+
+// FindRevision returns the ReplicaSet of a revision, revision 0 means the one before the current revision
+// like kubectl rollout undo does
+func FindRevision(replicaSets []v1apps.ReplicaSet, revision, currentRevision int64) (*v1apps.ReplicaSet, error) {
+	var previous *v1apps.ReplicaSet
+	for i := range replicaSets {
+		r := ReplicaSetRevision(replicaSets[i])
+		if revision != 0 && r == revision {
+			return &replicaSets[i], nil
+		}
+		if revision == 0 && r < currentRevision && (previous == nil || r > ReplicaSetRevision(*previous)) {
+			previous = &replicaSets[i]
+		}
+	}
+	if revision == 0 && previous != nil {
+		return previous, nil
+	}
+	if revision == 0 {
+		return nil, fmt.Errorf("no revision before %d", currentRevision)
+	}
+	return nil, fmt.Errorf("revision %d not found", revision)
+}
+
+// PodTemplateYAML renders a pod template without the hash label the deployment controller adds to every ReplicaSet
+func PodTemplateYAML(template v1core.PodTemplateSpec) (string, error) {
+	template = *template.DeepCopy()
+	delete(template.Labels, PodTemplateHashLabel)
+	d, err := json.Marshal(template)
+	if err != nil {
+		return "", err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(d, &m); err != nil {
+		return "", err
+	}
+	yamlStr, err := yaml.Marshal(&m)
+	if err != nil {
+		return "", err
+	}
+	return string(yamlStr), nil
+}
+
+// PodTemplateDiff returns a unified diff between the pod templates of two ReplicaSets
+func PodTemplateDiff(from, to v1apps.ReplicaSet) (string, error) {
+	fromYAML, err := PodTemplateYAML(from.Spec.Template)
+	if err != nil {
+		return "", err
+	}
+	toYAML, err := PodTemplateYAML(to.Spec.Template)
+	if err != nil {
+		return "", err
+	}
+	return UnifiedDiff(fromYAML, toYAML,
+		fmt.Sprintf("revision %d (%s)", ReplicaSetRevision(from), from.Name),
+		fmt.Sprintf("revision %d (%s)", ReplicaSetRevision(to), to.Name))
+}
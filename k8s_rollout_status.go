@@ -0,0 +1,263 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	logger "github.com/sirupsen/logrus"
+	v1apps "k8s.io/api/apps/v1"
+	v1core "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"time"
+)
+
+const (
+	RolloutActivityType = "Rollout"
+	// DefaultRolloutTimeout bounds tracking of workloads without a progress deadline
+	DefaultRolloutTimeout = 10 * time.Minute
+)
+
+// rolloutStatusFunc reports the progress of a rollout like kubectl rollout status does,
+// done is true once the rollout finished and err is set when it can not finish anymore
+type rolloutStatusFunc func(obj runtime.Object) (message string, done bool, timeout time.Duration, err error)
+
+var rolloutStatusFuncs = map[string]rolloutStatusFunc{
+	"Deployment": func(obj runtime.Object) (string, bool, time.Duration, error) {
+		deployment, ok := obj.(*v1apps.Deployment)
+		if !ok {
+			return "", false, 0, fmt.Errorf("unexpected object %T", obj)
+		}
+		timeout := DefaultRolloutTimeout
+		if deployment.Spec.ProgressDeadlineSeconds != nil {
+			// Leave the controller time to report the exceeded deadline
+			timeout = time.Duration(*deployment.Spec.ProgressDeadlineSeconds)*time.Second + time.Minute
+		}
+		message, done, err := DeploymentRolloutStatus(deployment)
+		return message, done, timeout, err
+	},
+	"StatefulSet": func(obj runtime.Object) (string, bool, time.Duration, error) {
+		statefulSet, ok := obj.(*v1apps.StatefulSet)
+		if !ok {
+			return "", false, 0, fmt.Errorf("unexpected object %T", obj)
+		}
+		message, done := StatefulSetRolloutStatus(statefulSet)
+		return message, done, DefaultRolloutTimeout, nil
+	},
+	"DaemonSet": func(obj runtime.Object) (string, bool, time.Duration, error) {
+		daemonSet, ok := obj.(*v1apps.DaemonSet)
+		if !ok {
+			return "", false, 0, fmt.Errorf("unexpected object %T", obj)
+		}
+		message, done := DaemonSetRolloutStatus(daemonSet)
+		return message, done, DefaultRolloutTimeout, nil
+	},
+	"ReplicaSet": func(obj runtime.Object) (string, bool, time.Duration, error) {
+		rs, ok := obj.(*v1apps.ReplicaSet)
+		if !ok {
+			return "", false, 0, fmt.Errorf("unexpected object %T", obj)
+		}
+		message, done := replicasStatus(rs.Generation, rs.Status.ObservedGeneration, rs.Spec.Replicas, rs.Status.Replicas, rs.Status.AvailableReplicas)
+		return message, done, DefaultRolloutTimeout, nil
+	},
+	"ReplicationController": func(obj runtime.Object) (string, bool, time.Duration, error) {
+		rc, ok := obj.(*v1core.ReplicationController)
+		if !ok {
+			return "", false, 0, fmt.Errorf("unexpected object %T", obj)
+		}
+		message, done := replicasStatus(rc.Generation, rc.Status.ObservedGeneration, rc.Spec.Replicas, rc.Status.Replicas, rc.Status.AvailableReplicas)
+		return message, done, DefaultRolloutTimeout, nil
+	},
+}
+
+// DeploymentRolloutStatus mirrors the status viewer of kubectl rollout status
+func DeploymentRolloutStatus(deployment *v1apps.Deployment) (string, bool, error) {
+	if deployment.Generation > deployment.Status.ObservedGeneration {
+		return "Waiting for deployment spec update to be observed", false, nil
+	}
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == v1apps.DeploymentProgressing && condition.Reason == "ProgressDeadlineExceeded" {
+			return "", false, fmt.Errorf("deployment %q exceeded its progress deadline", deployment.Name)
+		}
+	}
+	if deployment.Spec.Paused {
+		return "Deployment is paused", true, nil
+	}
+	var replicas int32 = 1
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	status := deployment.Status
+	if status.UpdatedReplicas < replicas {
+		return fmt.Sprintf("Waiting for rollout to finish: %d out of %d new replicas have been updated", status.UpdatedReplicas, replicas), false, nil
+	}
+	if status.Replicas > status.UpdatedReplicas {
+		return fmt.Sprintf("Waiting for rollout to finish: %d old replicas are pending termination", status.Replicas-status.UpdatedReplicas), false, nil
+	}
+	if status.AvailableReplicas < status.UpdatedReplicas {
+		return fmt.Sprintf("Waiting for rollout to finish: %d of %d updated replicas are available", status.AvailableReplicas, status.UpdatedReplicas), false, nil
+	}
+	return fmt.Sprintf("Successfully rolled out, %d of %d replicas are available", status.AvailableReplicas, replicas), true, nil
+}
+
+// StatefulSetRolloutStatus mirrors the status viewer of kubectl rollout status
+func StatefulSetRolloutStatus(statefulSet *v1apps.StatefulSet) (string, bool) {
+	if statefulSet.Spec.UpdateStrategy.Type != v1apps.RollingUpdateStatefulSetStrategyType {
+		return fmt.Sprintf("Rollout status is only available for %s strategy type", v1apps.RollingUpdateStatefulSetStrategyType), true
+	}
+	if statefulSet.Status.ObservedGeneration == 0 || statefulSet.Generation > statefulSet.Status.ObservedGeneration {
+		return "Waiting for statefulset spec update to be observed", false
+	}
+	var replicas int32 = 1
+	if statefulSet.Spec.Replicas != nil {
+		replicas = *statefulSet.Spec.Replicas
+	}
+	if statefulSet.Status.ReadyReplicas < replicas {
+		return fmt.Sprintf("Waiting for %d pods to be ready", replicas-statefulSet.Status.ReadyReplicas), false
+	}
+	if rollingUpdate := statefulSet.Spec.UpdateStrategy.RollingUpdate; rollingUpdate != nil && rollingUpdate.Partition != nil && *rollingUpdate.Partition > 0 {
+		partitioned := replicas - *rollingUpdate.Partition
+		if statefulSet.Status.UpdatedReplicas < partitioned {
+			return fmt.Sprintf("Waiting for partitioned roll out to finish: %d out of %d new pods have been updated", statefulSet.Status.UpdatedReplicas, partitioned), false
+		}
+		return fmt.Sprintf("Partitioned roll out complete: %d new pods have been updated", statefulSet.Status.UpdatedReplicas), true
+	}
+	if statefulSet.Status.UpdateRevision != statefulSet.Status.CurrentRevision {
+		return fmt.Sprintf("Waiting for statefulset rolling update to complete %d pods at revision %s", statefulSet.Status.UpdatedReplicas, statefulSet.Status.UpdateRevision), false
+	}
+	return fmt.Sprintf("Statefulset rolling update complete %d pods at revision %s", statefulSet.Status.CurrentReplicas, statefulSet.Status.CurrentRevision), true
+}
+
+// DaemonSetRolloutStatus mirrors the status viewer of kubectl rollout status
+func DaemonSetRolloutStatus(daemonSet *v1apps.DaemonSet) (string, bool) {
+	if daemonSet.Spec.UpdateStrategy.Type != v1apps.RollingUpdateDaemonSetStrategyType {
+		return fmt.Sprintf("Rollout status is only available for %s strategy type", v1apps.RollingUpdateDaemonSetStrategyType), true
+	}
+	if daemonSet.Generation > daemonSet.Status.ObservedGeneration {
+		return "Waiting for daemon set spec update to be observed", false
+	}
+	status := daemonSet.Status
+	if status.UpdatedNumberScheduled < status.DesiredNumberScheduled {
+		return fmt.Sprintf("Waiting for rollout to finish: %d out of %d new pods have been updated", status.UpdatedNumberScheduled, status.DesiredNumberScheduled), false
+	}
+	if status.NumberAvailable < status.DesiredNumberScheduled {
+		return fmt.Sprintf("Waiting for rollout to finish: %d of %d updated pods are available", status.NumberAvailable, status.DesiredNumberScheduled), false
+	}
+	return fmt.Sprintf("Successfully rolled out, %d of %d pods are available", status.NumberAvailable, status.DesiredNumberScheduled), true
+}
+
+// replicasStatus follows workloads without rolling updates until their replicas are available
+func replicasStatus(generation, observedGeneration int64, desired *int32, replicas, available int32) (string, bool) {
+	if generation > observedGeneration {
+		return "Waiting for spec update to be observed", false
+	}
+	var want int32 = 1
+	if desired != nil {
+		want = *desired
+	}
+	if replicas > want {
+		return fmt.Sprintf("Waiting for %d replicas to be terminated", replicas-want), false
+	}
+	if available < want {
+		return fmt.Sprintf("Waiting for replicas to be available: %d of %d", available, want), false
+	}
+	return fmt.Sprintf("%d of %d replicas are available", available, want), true
+}
+
+// TrackRollout watches the target of an operation in the background and logs every change of the rollout
+// progress to the activity console. The audit entry, when given, is finished with the outcome of the rollout.
+func TrackRollout(clientset *kubernetes.Clientset, operation *ActivityOperation, audit *AuditEntry) {
+	finish := func(err error) {
+		if err != nil {
+			logger.Warnf("Rollout of %s %s/%s failed: %v", operation.Target.Kind, operation.Target.Namespace, operation.Target.Name, err)
+			operation.Log(ActivityFailed, err.Error())
+		}
+		if audit != nil {
+			audit.Finish(err)
+		}
+	}
+
+	if !RolloutTrackable(operation.Target.Kind) {
+		finish(fmt.Errorf("rollout status of %s is not supported", operation.Target.Kind))
+		return
+	}
+	kind := watchKinds[operation.Target.Kind]
+	status := rolloutStatusFuncs[operation.Target.Kind]
+
+	go func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		opts := metav1.ListOptions{FieldSelector: fields.OneTermEqualSelector("metadata.name", operation.Target.Name).String()}
+		watcher, err := kind.watch(ctx, clientset, operation.Target.Namespace, opts)
+		if err != nil {
+			finish(err)
+			return
+		}
+		defer func() {
+			watcher.Stop()
+		}()
+
+		timer := time.NewTimer(DefaultRolloutTimeout)
+		defer timer.Stop()
+		timerSet := false
+		var lastMessage string
+		for {
+			select {
+			case <-timer.C:
+				finish(errors.New("timed out waiting for the rollout to finish"))
+				return
+			case event, ok := <-watcher.ResultChan():
+				if !ok {
+					// The API server closes watches after a while, a new watch starts with the current state
+					watcher, err = kind.watch(ctx, clientset, operation.Target.Namespace, opts)
+					if err != nil {
+						finish(err)
+						return
+					}
+					continue
+				}
+				switch event.Type {
+				case watch.Error:
+					finish(apierrors.FromObject(event.Object))
+					return
+				case watch.Deleted:
+					finish(fmt.Errorf("%s was deleted", operation.Target.Kind))
+					return
+				case watch.Added, watch.Modified:
+					message, done, timeout, err := status(event.Object)
+					if err != nil {
+						finish(err)
+						return
+					}
+					if !timerSet {
+						if !timer.Stop() {
+							<-timer.C
+						}
+						timer.Reset(timeout)
+						timerSet = true
+					}
+					if done {
+						operation.Log(ActivityCompleted, message)
+						finish(nil)
+						return
+					}
+					if message != lastMessage {
+						operation.Log(ActivityProgressing, message)
+						lastMessage = message
+					}
+				}
+			}
+		}
+	}()
+}
+
+// RolloutTrackable reports whether TrackRollout can follow workloads of the kind
+func RolloutTrackable(kind string) bool {
+	_, hasWatch := watchKinds[kind]
+	_, hasStatus := rolloutStatusFuncs[kind]
+	return hasWatch && hasStatus
+}
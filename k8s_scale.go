@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/scale"
+)
+
+const ScaleActivityType = "Scaling"
+
+// scalableKindGroups are the API groups of built-in kinds with a scale subresource, other kinds are given as Kind.group
+var scalableKindGroups = map[string]string{
+	"Deployment":            "apps",
+	"StatefulSet":           "apps",
+	"ReplicaSet":            "apps",
+	"ReplicationController": "",
+}
+
+// FindHPA returns the HorizontalPodAutoscaler managing a workload, nil when there is none.
+// The group is compared too, custom resources may reuse the names of built-in kinds.
+func FindHPA(clientset *kubernetes.Clientset, ns string, groupKind schema.GroupKind, name string) (*v2.HorizontalPodAutoscaler, error) {
+	hpas, err := clientset.AutoscalingV2().HorizontalPodAutoscalers(ns).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range hpas.Items {
+		ref := hpas.Items[i].Spec.ScaleTargetRef
+		if ref.Kind != groupKind.Kind || ref.Name != name {
+			continue
+		}
+		gv, err := schema.ParseGroupVersion(ref.APIVersion)
+		if err == nil && gv.Group == groupKind.Group {
+			return &hpas.Items[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func hpaMinReplicas(hpa *v2.HorizontalPodAutoscaler) int32 {
+	if hpa.Spec.MinReplicas != nil {
+		return *hpa.Spec.MinReplicas
+	}
+	return 1
+}
+
+// ValidateHPAReplicas refuses replicas outside the range of the autoscaler managing the workload,
+// it would scale them back right away. Any replicas are valid without an autoscaler.
+func ValidateHPAReplicas(hpa *v2.HorizontalPodAutoscaler, replicas int32) error {
+	if hpa == nil {
+		return nil
+	}
+	if minReplicas := hpaMinReplicas(hpa); replicas < minReplicas {
+		return fmt.Errorf("horizontal pod autoscaler %s allows at least %d replicas", hpa.Name, minReplicas)
+	}
+	if replicas > hpa.Spec.MaxReplicas {
+		return fmt.Errorf("horizontal pod autoscaler %s allows at most %d replicas", hpa.Name, hpa.Spec.MaxReplicas)
+	}
+	return nil
+}
+
+// HPAWarning explains that an autoscaler will override manually set replicas, empty without an autoscaler
+func HPAWarning(hpa *v2.HorizontalPodAutoscaler) string {
+	if hpa == nil {
+		return ""
+	}
+	return fmt.Sprintf("HorizontalPodAutoscaler %s manages the replicas between %d and %d and will override manual changes", hpa.Name, hpaMinReplicas(hpa), hpa.Spec.MaxReplicas)
+}
+
+// ScaleClient reads and updates the scale subresource of any kind serving one, including custom resources
+type ScaleClient struct {
+	scale.ScalesGetter
+	mapper meta.RESTMapper
+}
+
+func NewScaleClient(config *rest.Config, clientset *kubernetes.Clientset) (*ScaleClient, error) {
+	cachedDiscovery := memory.NewMemCacheClient(clientset.Discovery())
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(cachedDiscovery)
+	scalesGetter, err := scale.NewForConfig(config, mapper, dynamic.LegacyAPIPathResolverFunc, scale.NewDiscoveryScaleKindResolver(cachedDiscovery))
+	if err != nil {
+		return nil, err
+	}
+	return &ScaleClient{
+		ScalesGetter: scalesGetter,
+		mapper:       mapper,
+	}, nil
+}
+
+// Resource resolves a kind like StatefulSet or Rollout.argoproj.io to its resource
+func (c *ScaleClient) Resource(kind string) (schema.GroupResource, schema.GroupKind, error) {
+	groupKind := schema.ParseGroupKind(kind)
+	if group, ok := scalableKindGroups[groupKind.Kind]; ok && groupKind.Group == "" {
+		groupKind.Group = group
+	}
+	mapping, err := c.mapper.RESTMapping(groupKind)
+	if err != nil {
+		return schema.GroupResource{}, groupKind, err
+	}
+	return mapping.Resource.GroupResource(), groupKind, nil
+}
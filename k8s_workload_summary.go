@@ -0,0 +1,267 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"strings"
+	"time"
+)
+
+// Building blocks shared by the detail views of workloads and pods
+
+type SummaryPod struct {
+	Name         string `json:"name"`
+	Ready        int    `json:"ready"`
+	ReadyDesired int    `json:"ready_desired"`
+	Phase        string `json:"phase"`
+	Status       string `json:"status"`
+	Restarts     int32  `json:"restarts"`
+	Node         string `json:"node"`
+	Age          string `json:"age"`
+}
+
+type SummaryContainerEnvironment struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+}
+
+type SummaryContainerVolume struct {
+	Name        string `json:"name"`
+	Path        string `json:"path"`
+	Propagation string `json:"propagation"`
+}
+
+type SummaryContainerPort struct {
+	Port     int32  `json:"port"`
+	Protocol string `json:"protocol"`
+}
+
+type SummaryContainer struct {
+	ContainerName string                        `json:"container_name"`
+	Image         string                        `json:"image"`
+	Ports         []SummaryContainerPort        `json:"ports"`
+	Environment   []SummaryContainerEnvironment `json:"environment"`
+	Volume        []SummaryContainerVolume      `json:"volume"`
+}
+
+type SummaryVolume struct {
+	Name        string `json:"name"`
+	Kind        string `json:"kind"`
+	Description string `json:"description"`
+}
+
+type SummaryCondition struct {
+	Type           string `json:"type"`
+	Reason         string `json:"reason"`
+	Status         string `json:"status"`
+	Message        string `json:"message"`
+	LastUpdate     string `json:"last_update"`
+	LastTransition string `json:"last_transition"`
+}
+
+func NewSummaryCondition(conditionType, status, reason, message string, lastUpdate, lastTransition time.Time) SummaryCondition {
+	condition := SummaryCondition{
+		Type:           conditionType,
+		Reason:         reason,
+		Status:         status,
+		Message:        message,
+		LastTransition: ElapsedTimeShort(lastTransition),
+	}
+	if !lastUpdate.IsZero() {
+		condition.LastUpdate = ElapsedTimeShort(lastUpdate)
+	}
+	return condition
+}
+
+func NewSummaryPod(pod v1.Pod) SummaryPod {
+	var latestCondition v1.PodCondition
+	if len(pod.Status.Conditions) > 0 {
+		latestCondition = pod.Status.Conditions[0]
+		for _, condition := range pod.Status.Conditions {
+			if condition.LastTransitionTime.After(latestCondition.LastTransitionTime.Time) {
+				latestCondition = condition
+			}
+		}
+	}
+	readyContainers := 0
+	var restarts int32
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Ready {
+			readyContainers++
+		}
+		restarts += status.RestartCount
+	}
+	return SummaryPod{
+		Name:         pod.Name,
+		Ready:        readyContainers,
+		ReadyDesired: len(pod.Spec.Containers),
+		Phase:        string(pod.Status.Phase),
+		Status:       string(latestCondition.Status),
+		Restarts:     restarts,
+		Node:         pod.Spec.NodeName,
+		Age:          ElapsedTimeShort(pod.CreationTimestamp.Time),
+	}
+}
+
+func NewSummaryPods(pods []v1.Pod) []SummaryPod {
+	result := make([]SummaryPod, 0)
+	for _, pod := range pods {
+		result = append(result, NewSummaryPod(pod))
+	}
+	return result
+}
+
+func NewSummaryContainer(container v1.Container) SummaryContainer {
+	summaryContainer := SummaryContainer{
+		ContainerName: container.Name,
+		Image:         container.Image,
+		Ports:         make([]SummaryContainerPort, 0),
+		Environment:   make([]SummaryContainerEnvironment, 0),
+		Volume:        make([]SummaryContainerVolume, 0),
+	}
+
+	for _, port := range container.Ports {
+		summaryContainer.Ports = append(summaryContainer.Ports, SummaryContainerPort{
+			Port:     port.ContainerPort,
+			Protocol: string(port.Protocol),
+		})
+	}
+
+	for _, env := range container.Env {
+		pEnv := SummaryContainerEnvironment{
+			Name:  env.Name,
+			Value: env.Value,
+		}
+		if env.ValueFrom != nil {
+			if env.ValueFrom.FieldRef != nil {
+				pEnv.Source = env.ValueFrom.FieldRef.FieldPath
+			}
+			if env.ValueFrom.ResourceFieldRef != nil {
+				pEnv.Source = env.ValueFrom.ResourceFieldRef.Resource
+			}
+			if env.ValueFrom.ConfigMapKeyRef != nil {
+				pEnv.Source = env.ValueFrom.ConfigMapKeyRef.Name
+			}
+			if env.ValueFrom.SecretKeyRef != nil {
+				pEnv.Source = env.ValueFrom.SecretKeyRef.Name
+			}
+		}
+		summaryContainer.Environment = append(summaryContainer.Environment, pEnv)
+	}
+
+	for _, volumeMount := range container.VolumeMounts {
+		volume := SummaryContainerVolume{
+			Name: volumeMount.Name,
+			Path: volumeMount.MountPath,
+		}
+		if volumeMount.MountPropagation != nil {
+			volume.Propagation = string(*volumeMount.MountPropagation)
+		} else {
+			volume.Propagation = "None"
+		}
+		summaryContainer.Volume = append(summaryContainer.Volume, volume)
+	}
+
+	return summaryContainer
+}
+
+func NewSummaryContainers(containers []v1.Container) []SummaryContainer {
+	result := make([]SummaryContainer, 0)
+	for _, container := range containers {
+		result = append(result, NewSummaryContainer(container))
+	}
+	return result
+}
+
+func NewSummaryVolumes(volumes []v1.Volume) []SummaryVolume {
+	result := make([]SummaryVolume, 0)
+	for _, volume := range volumes {
+		v := SummaryVolume{
+			Name: volume.Name,
+		}
+		if volume.PersistentVolumeClaim != nil {
+			v.Kind = "PersistentVolumeClaim"
+			v.Description = volume.PersistentVolumeClaim.ClaimName
+		}
+		if volume.Secret != nil {
+			v.Kind = "Secret"
+			v.Description = volume.Secret.SecretName
+		}
+		if volume.ConfigMap != nil {
+			v.Kind = "ConfigMap"
+			v.Description = volume.ConfigMap.Name
+		}
+		if volume.EmptyDir != nil {
+			v.Kind = "EmptyDir"
+		}
+		if volume.HostPath != nil {
+			v.Kind = "HostPath"
+			v.Description = volume.HostPath.Path
+		}
+		if volume.Projected != nil {
+			v.Kind = "Projected"
+		}
+		if volume.DownwardAPI != nil {
+			v.Kind = "DownwardAPI"
+		}
+		if volume.Ephemeral != nil {
+			v.Kind = "Ephemeral"
+		}
+		result = append(result, v)
+	}
+	return result
+}
+
+// ListSelectedPods lists the pods of a workload by its label selector
+func ListSelectedPods(clientset *kubernetes.Clientset, ns string, selector *metav1.LabelSelector) ([]v1.Pod, error) {
+	labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := clientset.CoreV1().Pods(ns).List(context.Background(), metav1.ListOptions{LabelSelector: labelSelector.String()})
+	if err != nil {
+		return nil, err
+	}
+	return pods.Items, nil
+}
+
+// FormatProbe describes a probe the way kubectl describe does
+func FormatProbe(probe *v1.Probe) string {
+	if probe == nil {
+		return ""
+	}
+	var action string
+	switch {
+	case probe.HTTPGet != nil:
+		scheme := strings.ToLower(string(probe.HTTPGet.Scheme))
+		if scheme == "" {
+			scheme = "http"
+		}
+		action = fmt.Sprintf("http-get %s://%s:%s%s", scheme, probe.HTTPGet.Host, probe.HTTPGet.Port.String(), probe.HTTPGet.Path)
+	case probe.TCPSocket != nil:
+		action = fmt.Sprintf("tcp-socket %s:%s", probe.TCPSocket.Host, probe.TCPSocket.Port.String())
+	case probe.GRPC != nil:
+		action = fmt.Sprintf("grpc <pod>:%d", probe.GRPC.Port)
+	case probe.Exec != nil:
+		action = "exec " + strings.Join(probe.Exec.Command, " ")
+	default:
+		action = "unknown"
+	}
+	return fmt.Sprintf("%s delay=%ds timeout=%ds period=%ds #success=%d #failure=%d", action,
+		probe.InitialDelaySeconds, probe.TimeoutSeconds, probe.PeriodSeconds, probe.SuccessThreshold, probe.FailureThreshold)
+}
+
+// ControlledPods keeps the pods whose controller is the given owner, selectors of different workloads may overlap
+func ControlledPods(pods []v1.Pod, owner metav1.Object) []v1.Pod {
+	result := make([]v1.Pod, 0)
+	for i := range pods {
+		if metav1.IsControlledBy(&pods[i], owner) {
+			result = append(result, pods[i])
+		}
+	}
+	return result
+}
@@ -5,6 +5,13 @@ import (
 	"time"
 )
 
+const (
+	ActivityRequested   = "requested"
+	ActivityProgressing = "progressing"
+	ActivityCompleted   = "completed"
+	ActivityFailed      = "failed"
+)
+
 func LogActivityConsoleAdd(msg, msgType string) {
 	data := LogActivity{
 		Type:    msgType,
@@ -15,3 +22,36 @@ func LogActivityConsoleAdd(msg, msgType string) {
 		logger.Warnf("Failed to save log activity: %v", err)
 	}
 }
+
+// ActivityOperation groups the activity entries of a long running operation like a scale or a rollout,
+// every entry carries the state the operation reached
+type ActivityOperation struct {
+	ID          string
+	Type        string
+	Description string
+	Target      AuditTarget
+}
+
+// NewActivityOperation starts an operation, description prefixes every message, e.g. "Scale of Deployment default/web"
+func NewActivityOperation(msgType, description string, target AuditTarget) *ActivityOperation {
+	return &ActivityOperation{
+		ID:          GenerateRandomString(16),
+		Type:        msgType,
+		Description: description,
+		Target:      target,
+	}
+}
+
+func (o *ActivityOperation) Log(state, msg string) {
+	data := LogActivity{
+		Time:        time.Now(),
+		Type:        o.Type,
+		Message:     o.Description + ": " + msg,
+		OperationID: o.ID,
+		State:       state,
+		Target:      &o.Target,
+	}
+	if err := DBHelper.InsertOne(ActivityConsole, data); err != nil {
+		logger.Warnf("Failed to save log activity: %v", err)
+	}
+}
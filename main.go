@@ -27,6 +27,9 @@ func main() {
 	logger.Info("Successfully connected to database")
 
 	DBHelper = NewDatabaseHelper(databaseClient.Database(database))
+	K8sCache = NewInformerCache(CacheSettings)
+	StartUsageSampler(UsageSettings)
+	StartQuotaWatcher(QuotaSettings)
 
 	signals := make(chan os.Signal, 1)
 	signal.Notify(signals, syscall.SIGTERM, syscall.SIGKILL, syscall.SIGINT)
@@ -68,6 +71,7 @@ func main() {
 	//	- filters: User filters (UserSavedFilters)
 	//	- admin: Admin logged in (bool)
 	//	- profile: User profile (UserProfileStruct)
+	//	- role: User role (viewer, operator or admin) set at login, see RequireRole
 	var secureSessionKey DataSecureSessionKey
 	filter := BsonExists("secure_session_key")
 	if err := DBHelper.FindOne(DataCollection, filter, &secureSessionKey); err != nil {
@@ -105,7 +109,7 @@ func main() {
 					c.Logger().Warnf("Failed to send status not found error: %v", err)
 				}
 			} else {
-				if err := c.Redirect(http.StatusMovedPermanently, "http://localhost:3000/notFound"); err != nil {
+				if err := c.Redirect(http.StatusMovedPermanently, FrontendOrigin+"/notFound"); err != nil {
 					c.Logger().Warnf("Failed to perform redirect to not found page: %v", err)
 				}
 			}
@@ -118,12 +122,22 @@ func main() {
 
 	webServerGroup := webServer.Group("")
 	webServerGroup.Use(middleware.CORSWithConfig(middleware.CORSConfig{
-		AllowOrigins:  []string{"http://localhost:3000"},
+		AllowOrigins:  []string{FrontendOrigin},
 		AllowMethods:  []string{echo.GET, echo.PUT, echo.POST, echo.DELETE},
 		AllowHeaders:  []string{"*"},
 		ExposeHeaders: []string{echo.HeaderContentType},
 	}))
 
+	webServerGroup.POST("/login", func(c echo.Context) error {
+		handler := &LoginHandler{}
+		return handler.ServeHTTP(c)
+	})
+
+	webServerGroup.POST("/logout", func(c echo.Context) error {
+		handler := &LogoutHandler{}
+		return handler.ServeHTTP(c)
+	})
+
 	webServerGroup.GET("/isInEditMode", func(c echo.Context) error {
 		IsConfigurationMode()
 		return c.JSON(http.StatusOK, ConfigurationMode)
@@ -153,8 +167,75 @@ func main() {
 			Deployment: c.Param("deployment"),
 		}
 		return handler.ServeHTTP(c)
+	}, RequireRole(RoleOperator))
+
+	webServerGroup.GET("/getK8sscale/:id/:name/:ns/:kind/:object", func(c echo.Context) error {
+		handler := &GetK8sScaleHandler{
+			ID:     c.Param("id"),
+			Name:   c.Param("name"),
+			NS:     c.Param("ns"),
+			Kind:   c.Param("kind"),
+			Object: c.Param("object"),
+		}
+		return handler.ServeHTTP(c)
 	})
 
+	webServerGroup.POST("/scaleK8s/:id/:name/:ns/:kind/:object", func(c echo.Context) error {
+		handler := &ScaleK8sResourceHandler{
+			ID:     c.Param("id"),
+			Name:   c.Param("name"),
+			NS:     c.Param("ns"),
+			Kind:   c.Param("kind"),
+			Object: c.Param("object"),
+		}
+		return handler.ServeHTTP(c)
+	}, RequireRole(RoleOperator))
+
+	webServerGroup.POST("/rolloutK8s/:id/:name/:ns/:kind/:object/:action", func(c echo.Context) error {
+		handler := &RolloutK8sResourceHandler{
+			ID:     c.Param("id"),
+			Name:   c.Param("name"),
+			NS:     c.Param("ns"),
+			Kind:   c.Param("kind"),
+			Object: c.Param("object"),
+			Action: c.Param("action"),
+		}
+		return handler.ServeHTTP(c)
+	}, RequireRole(RoleOperator))
+
+	webServerGroup.DELETE("/deleteK8s/:id/:name/:ns/:kind/:object", func(c echo.Context) error {
+		handler := &DeleteK8sResourceHandler{
+			ID:          c.Param("id"),
+			Name:        c.Param("name"),
+			NS:          c.Param("ns"),
+			Kind:        c.Param("kind"),
+			Object:      c.Param("object"),
+			Cascade:     c.QueryParam("cascade"),
+			GracePeriod: c.QueryParam("grace_period"),
+			DryRun:      c.QueryParam("dry_run") == "true",
+		}
+		return handler.ServeHTTP(c)
+	}, RequireRole(RoleOperator))
+
+	webServerGroup.POST("/evictK8sPod/:id/:name/:ns/:pod", func(c echo.Context) error {
+		handler := &EvictK8sPodHandler{
+			ID:   c.Param("id"),
+			Name: c.Param("name"),
+			NS:   c.Param("ns"),
+			Pod:  c.Param("pod"),
+		}
+		return handler.ServeHTTP(c)
+	}, RequireRole(RoleOperator))
+
+	webServerGroup.POST("/applyK8s/:id/:name/:ns", func(c echo.Context) error {
+		handler := &ApplyK8sResourceHandler{
+			ID:   c.Param("id"),
+			Name: c.Param("name"),
+			NS:   c.Param("ns"),
+		}
+		return handler.ServeHTTP(c)
+	}, RequireRole(RoleOperator))
+
 	webServerGroup.GET("/lac", LogActivityConsole)
 
 	webServerGroup.GET("/lac-data", LogActivityConsoleData)
@@ -172,6 +253,15 @@ func main() {
 		return handler.ServeHTTP(c)
 	})
 
+	webServerGroup.GET("/getK8snamespaceInfo/:id/:name/:ns", func(c echo.Context) error {
+		handler := &GetK8sNamespaceInfoHandler{
+			ID:   c.Param("id"),
+			Name: c.Param("name"),
+			NS:   c.Param("ns"),
+		}
+		return handler.ServeHTTP(c)
+	})
+
 	webServerGroup.GET("/getK8sdeployments/:id/:name/:ns", func(c echo.Context) error {
 		handler := &GetK8sDeploymentsHandler{
 			ID:   c.Param("id"),
@@ -191,6 +281,38 @@ func main() {
 		return handler.ServeHTTP(c)
 	})
 
+	webServerGroup.GET("/getK8sdeploymentHistory/:id/:name/:ns/:deployment", func(c echo.Context) error {
+		handler := &GetK8sDeploymentHistoryHandler{
+			ID:         c.Param("id"),
+			Name:       c.Param("name"),
+			NS:         c.Param("ns"),
+			Deployment: c.Param("deployment"),
+		}
+		return handler.ServeHTTP(c)
+	})
+
+	webServerGroup.GET("/getK8sdeploymentRevisionDiff/:id/:name/:ns/:deployment", func(c echo.Context) error {
+		handler := &GetK8sDeploymentRevisionDiffHandler{
+			ID:         c.Param("id"),
+			Name:       c.Param("name"),
+			NS:         c.Param("ns"),
+			Deployment: c.Param("deployment"),
+			From:       c.QueryParam("from"),
+			To:         c.QueryParam("to"),
+		}
+		return handler.ServeHTTP(c)
+	})
+
+	webServerGroup.POST("/rollbackDeployment/:id/:name/:ns/:deployment", func(c echo.Context) error {
+		handler := &RollbackDeploymentHandler{
+			ID:         c.Param("id"),
+			Name:       c.Param("name"),
+			NS:         c.Param("ns"),
+			Deployment: c.Param("deployment"),
+		}
+		return handler.ServeHTTP(c)
+	}, RequireRole(RoleOperator))
+
 	webServerGroup.GET("/getK8sstateFulSets/:id/:name/:ns", func(c echo.Context) error {
 		handler := &GetK8sStateFulSetsHandler{
 			ID:   c.Param("id"),
@@ -200,6 +322,16 @@ func main() {
 		return handler.ServeHTTP(c)
 	})
 
+	webServerGroup.GET("/getK8sstateFulSetInfo/:id/:name/:ns/:stateFulSet", func(c echo.Context) error {
+		handler := &GetK8sStateFulSetInfoHandler{
+			ID:          c.Param("id"),
+			Name:        c.Param("name"),
+			NS:          c.Param("ns"),
+			StateFulSet: c.Param("stateFulSet"),
+		}
+		return handler.ServeHTTP(c)
+	})
+
 	webServerGroup.GET("/getK8sdaemonSets/:id/:name/:ns", func(c echo.Context) error {
 		handler := &GetK8sDaemonSetsHandler{
 			ID:   c.Param("id"),
@@ -209,6 +341,16 @@ func main() {
 		return handler.ServeHTTP(c)
 	})
 
+	webServerGroup.GET("/getK8sdaemonSetInfo/:id/:name/:ns/:daemonSet", func(c echo.Context) error {
+		handler := &GetK8sDaemonSetInfoHandler{
+			ID:        c.Param("id"),
+			Name:      c.Param("name"),
+			NS:        c.Param("ns"),
+			DaemonSet: c.Param("daemonSet"),
+		}
+		return handler.ServeHTTP(c)
+	})
+
 	webServerGroup.GET("/getK8sjobs/:id/:name/:ns", func(c echo.Context) error {
 		handler := &GetK8sJobsHandler{
 			ID:   c.Param("id"),
@@ -218,6 +360,16 @@ func main() {
 		return handler.ServeHTTP(c)
 	})
 
+	webServerGroup.GET("/getK8sjobInfo/:id/:name/:ns/:job", func(c echo.Context) error {
+		handler := &GetK8sJobInfoHandler{
+			ID:   c.Param("id"),
+			Name: c.Param("name"),
+			NS:   c.Param("ns"),
+			Job:  c.Param("job"),
+		}
+		return handler.ServeHTTP(c)
+	})
+
 	webServerGroup.GET("/getK8scronJobs/:id/:name/:ns", func(c echo.Context) error {
 		handler := &GetK8sCronJobsHandler{
 			ID:   c.Param("id"),
@@ -227,6 +379,36 @@ func main() {
 		return handler.ServeHTTP(c)
 	})
 
+	webServerGroup.GET("/getK8scronJobInfo/:id/:name/:ns/:cronJob", func(c echo.Context) error {
+		handler := &GetK8sCronJobInfoHandler{
+			ID:      c.Param("id"),
+			Name:    c.Param("name"),
+			NS:      c.Param("ns"),
+			CronJob: c.Param("cronJob"),
+		}
+		return handler.ServeHTTP(c)
+	})
+
+	webServerGroup.POST("/suspendCronJob/:id/:name/:ns/:cronJob", func(c echo.Context) error {
+		handler := &SuspendCronJobHandler{
+			ID:      c.Param("id"),
+			Name:    c.Param("name"),
+			NS:      c.Param("ns"),
+			CronJob: c.Param("cronJob"),
+		}
+		return handler.ServeHTTP(c)
+	}, RequireRole(RoleOperator))
+
+	webServerGroup.POST("/triggerCronJob/:id/:name/:ns/:cronJob", func(c echo.Context) error {
+		handler := &TriggerCronJobHandler{
+			ID:      c.Param("id"),
+			Name:    c.Param("name"),
+			NS:      c.Param("ns"),
+			CronJob: c.Param("cronJob"),
+		}
+		return handler.ServeHTTP(c)
+	}, RequireRole(RoleOperator))
+
 	webServerGroup.GET("/getK8spods/:id/:name/:ns", func(c echo.Context) error {
 		handler := &GetK8sPodsHandler{
 			ID:   c.Param("id"),
@@ -236,6 +418,16 @@ func main() {
 		return handler.ServeHTTP(c)
 	})
 
+	webServerGroup.GET("/getK8spodInfo/:id/:name/:ns/:pod", func(c echo.Context) error {
+		handler := &GetK8sPodInfoHandler{
+			ID:   c.Param("id"),
+			Name: c.Param("name"),
+			NS:   c.Param("ns"),
+			Pod:  c.Param("pod"),
+		}
+		return handler.ServeHTTP(c)
+	})
+
 	webServerGroup.GET("/getK8sreplicaSets/:id/:name/:ns", func(c echo.Context) error {
 		handler := &GetK8sReplicaSetsHandler{
 			ID:   c.Param("id"),
@@ -254,6 +446,430 @@ func main() {
 		return handler.ServeHTTP(c)
 	})
 
+	webServerGroup.GET("/getK8sservices/:id/:name/:ns", func(c echo.Context) error {
+		handler := &GetK8sServicesHandler{
+			ID:   c.Param("id"),
+			Name: c.Param("name"),
+			NS:   c.Param("ns"),
+		}
+		return handler.ServeHTTP(c)
+	})
+
+	webServerGroup.GET("/getK8sserviceInfo/:id/:name/:ns/:service", func(c echo.Context) error {
+		handler := &GetK8sServiceInfoHandler{
+			ID:      c.Param("id"),
+			Name:    c.Param("name"),
+			NS:      c.Param("ns"),
+			Service: c.Param("service"),
+		}
+		return handler.ServeHTTP(c)
+	})
+
+	webServerGroup.GET("/getK8singresses/:id/:name/:ns", func(c echo.Context) error {
+		handler := &GetK8sIngressesHandler{
+			ID:   c.Param("id"),
+			Name: c.Param("name"),
+			NS:   c.Param("ns"),
+		}
+		return handler.ServeHTTP(c)
+	})
+
+	webServerGroup.GET("/getK8singressInfo/:id/:name/:ns/:ingress", func(c echo.Context) error {
+		handler := &GetK8sIngressInfoHandler{
+			ID:      c.Param("id"),
+			Name:    c.Param("name"),
+			NS:      c.Param("ns"),
+			Ingress: c.Param("ingress"),
+		}
+		return handler.ServeHTTP(c)
+	})
+
+	webServerGroup.GET("/getK8sendpointSlices/:id/:name/:ns", func(c echo.Context) error {
+		handler := &GetK8sEndpointSlicesHandler{
+			ID:   c.Param("id"),
+			Name: c.Param("name"),
+			NS:   c.Param("ns"),
+		}
+		return handler.ServeHTTP(c)
+	})
+
+	webServerGroup.GET("/getK8sendpointSliceInfo/:id/:name/:ns/:endpointSlice", func(c echo.Context) error {
+		handler := &GetK8sEndpointSliceInfoHandler{
+			ID:            c.Param("id"),
+			Name:          c.Param("name"),
+			NS:            c.Param("ns"),
+			EndpointSlice: c.Param("endpointSlice"),
+		}
+		return handler.ServeHTTP(c)
+	})
+
+	webServerGroup.GET("/getK8snetworkPolicies/:id/:name/:ns", func(c echo.Context) error {
+		handler := &GetK8sNetworkPoliciesHandler{
+			ID:   c.Param("id"),
+			Name: c.Param("name"),
+			NS:   c.Param("ns"),
+		}
+		return handler.ServeHTTP(c)
+	})
+
+	webServerGroup.GET("/getK8snetworkPolicyInfo/:id/:name/:ns/:networkPolicy", func(c echo.Context) error {
+		handler := &GetK8sNetworkPolicyInfoHandler{
+			ID:            c.Param("id"),
+			Name:          c.Param("name"),
+			NS:            c.Param("ns"),
+			NetworkPolicy: c.Param("networkPolicy"),
+		}
+		return handler.ServeHTTP(c)
+	})
+
+	webServerGroup.GET("/getK8sconfigMaps/:id/:name/:ns", func(c echo.Context) error {
+		handler := &GetK8sConfigMapsHandler{
+			ID:   c.Param("id"),
+			Name: c.Param("name"),
+			NS:   c.Param("ns"),
+		}
+		return handler.ServeHTTP(c)
+	})
+
+	webServerGroup.GET("/getK8sconfigMapInfo/:id/:name/:ns/:configMap", func(c echo.Context) error {
+		handler := &GetK8sConfigMapInfoHandler{
+			ID:        c.Param("id"),
+			Name:      c.Param("name"),
+			NS:        c.Param("ns"),
+			ConfigMap: c.Param("configMap"),
+		}
+		return handler.ServeHTTP(c)
+	})
+
+	webServerGroup.GET("/getK8ssecrets/:id/:name/:ns", func(c echo.Context) error {
+		handler := &GetK8sSecretsHandler{
+			ID:   c.Param("id"),
+			Name: c.Param("name"),
+			NS:   c.Param("ns"),
+		}
+		return handler.ServeHTTP(c)
+	})
+
+	webServerGroup.GET("/getK8ssecretInfo/:id/:name/:ns/:secret", func(c echo.Context) error {
+		handler := &GetK8sSecretInfoHandler{
+			ID:     c.Param("id"),
+			Name:   c.Param("name"),
+			NS:     c.Param("ns"),
+			Secret: c.Param("secret"),
+		}
+		return handler.ServeHTTP(c)
+	})
+
+	webServerGroup.GET("/getK8spersistentVolumeClaims/:id/:name/:ns", func(c echo.Context) error {
+		handler := &GetK8sPersistentVolumeClaimsHandler{
+			ID:   c.Param("id"),
+			Name: c.Param("name"),
+			NS:   c.Param("ns"),
+		}
+		return handler.ServeHTTP(c)
+	})
+
+	webServerGroup.GET("/getK8spersistentVolumeClaimInfo/:id/:name/:ns/:persistentVolumeClaim", func(c echo.Context) error {
+		handler := &GetK8sPersistentVolumeClaimInfoHandler{
+			ID:                    c.Param("id"),
+			Name:                  c.Param("name"),
+			NS:                    c.Param("ns"),
+			PersistentVolumeClaim: c.Param("persistentVolumeClaim"),
+		}
+		return handler.ServeHTTP(c)
+	})
+
+	webServerGroup.GET("/getK8spersistentVolumes/:id/:name", func(c echo.Context) error {
+		handler := &GetK8sPersistentVolumesHandler{
+			ID:   c.Param("id"),
+			Name: c.Param("name"),
+		}
+		return handler.ServeHTTP(c)
+	})
+
+	webServerGroup.GET("/getK8spersistentVolumeInfo/:id/:name/:persistentVolume", func(c echo.Context) error {
+		handler := &GetK8sPersistentVolumeInfoHandler{
+			ID:               c.Param("id"),
+			Name:             c.Param("name"),
+			PersistentVolume: c.Param("persistentVolume"),
+		}
+		return handler.ServeHTTP(c)
+	})
+
+	webServerGroup.GET("/getK8sstorageClasses/:id/:name", func(c echo.Context) error {
+		handler := &GetK8sStorageClassesHandler{
+			ID:   c.Param("id"),
+			Name: c.Param("name"),
+		}
+		return handler.ServeHTTP(c)
+	})
+
+	webServerGroup.GET("/getK8sstorageClassInfo/:id/:name/:storageClass", func(c echo.Context) error {
+		handler := &GetK8sStorageClassInfoHandler{
+			ID:           c.Param("id"),
+			Name:         c.Param("name"),
+			StorageClass: c.Param("storageClass"),
+		}
+		return handler.ServeHTTP(c)
+	})
+
+	webServerGroup.GET("/getK8shelmReleases/:id/:name/:ns", func(c echo.Context) error {
+		handler := &GetK8sHelmReleasesHandler{
+			ID:   c.Param("id"),
+			Name: c.Param("name"),
+			NS:   c.Param("ns"),
+		}
+		return handler.ServeHTTP(c)
+	})
+
+	webServerGroup.GET("/getK8shelmReleaseInfo/:id/:name/:ns/:release", func(c echo.Context) error {
+		handler := &GetK8sHelmReleaseInfoHandler{
+			ID:      c.Param("id"),
+			Name:    c.Param("name"),
+			NS:      c.Param("ns"),
+			Release: c.Param("release"),
+		}
+		return handler.ServeHTTP(c)
+	})
+
+	webServerGroup.GET("/getK8sroles/:id/:name/:ns", func(c echo.Context) error {
+		handler := &GetK8sRolesHandler{
+			ID:   c.Param("id"),
+			Name: c.Param("name"),
+			NS:   c.Param("ns"),
+		}
+		return handler.ServeHTTP(c)
+	})
+
+	webServerGroup.GET("/getK8sroleBindings/:id/:name/:ns", func(c echo.Context) error {
+		handler := &GetK8sRoleBindingsHandler{
+			ID:   c.Param("id"),
+			Name: c.Param("name"),
+			NS:   c.Param("ns"),
+		}
+		return handler.ServeHTTP(c)
+	})
+
+	webServerGroup.GET("/getK8sclusterRoles/:id/:name", func(c echo.Context) error {
+		handler := &GetK8sClusterRolesHandler{
+			ID:   c.Param("id"),
+			Name: c.Param("name"),
+		}
+		return handler.ServeHTTP(c)
+	})
+
+	webServerGroup.GET("/getK8sclusterRoleBindings/:id/:name", func(c echo.Context) error {
+		handler := &GetK8sClusterRoleBindingsHandler{
+			ID:   c.Param("id"),
+			Name: c.Param("name"),
+		}
+		return handler.ServeHTTP(c)
+	})
+
+	webServerGroup.GET("/getK8swhoCan/:id/:name/:ns", func(c echo.Context) error {
+		handler := &GetK8sWhoCanHandler{
+			ID:       c.Param("id"),
+			Name:     c.Param("name"),
+			NS:       c.Param("ns"),
+			Verb:     c.QueryParam("verb"),
+			Group:    c.QueryParam("group"),
+			Resource: c.QueryParam("resource"),
+		}
+		return handler.ServeHTTP(c)
+	})
+
+	webServerGroup.GET("/getK8sclusterWhoCan/:id/:name", func(c echo.Context) error {
+		handler := &GetK8sWhoCanHandler{
+			ID:       c.Param("id"),
+			Name:     c.Param("name"),
+			Verb:     c.QueryParam("verb"),
+			Group:    c.QueryParam("group"),
+			Resource: c.QueryParam("resource"),
+		}
+		return handler.ServeHTTP(c)
+	})
+
+	webServerGroup.GET("/getK8seffectiveRules/:id/:name/:kind/:subject", func(c echo.Context) error {
+		handler := &GetK8sEffectiveRulesHandler{
+			ID:      c.Param("id"),
+			Name:    c.Param("name"),
+			Kind:    c.Param("kind"),
+			Subject: c.Param("subject"),
+			NS:      c.QueryParam("namespace"),
+		}
+		return handler.ServeHTTP(c)
+	})
+
+	webServerGroup.GET("/getK8snodes/:id/:name", func(c echo.Context) error {
+		handler := &GetK8sNodesHandler{
+			ID:   c.Param("id"),
+			Name: c.Param("name"),
+		}
+		return handler.ServeHTTP(c)
+	})
+
+	webServerGroup.GET("/getK8snodeInfo/:id/:name/:node", func(c echo.Context) error {
+		handler := &GetK8sNodeInfoHandler{
+			ID:   c.Param("id"),
+			Name: c.Param("name"),
+			Node: c.Param("node"),
+		}
+		return handler.ServeHTTP(c)
+	})
+
+	webServerGroup.POST("/cordonK8sNode/:id/:name/:node", func(c echo.Context) error {
+		handler := &CordonK8sNodeHandler{
+			ID:            c.Param("id"),
+			Name:          c.Param("name"),
+			Node:          c.Param("node"),
+			Unschedulable: true,
+		}
+		return handler.ServeHTTP(c)
+	}, RequireRole(RoleOperator))
+
+	webServerGroup.POST("/uncordonK8sNode/:id/:name/:node", func(c echo.Context) error {
+		handler := &CordonK8sNodeHandler{
+			ID:   c.Param("id"),
+			Name: c.Param("name"),
+			Node: c.Param("node"),
+		}
+		return handler.ServeHTTP(c)
+	}, RequireRole(RoleOperator))
+
+	webServerGroup.POST("/drainK8sNode/:id/:name/:node", func(c echo.Context) error {
+		handler := &DrainK8sNodeHandler{
+			ID:   c.Param("id"),
+			Name: c.Param("name"),
+			Node: c.Param("node"),
+		}
+		return handler.ServeHTTP(c)
+	}, RequireRole(RoleOperator))
+
+	webServerGroup.GET("/getK8sevents/:id/:name/:ns/:kind/:object", func(c echo.Context) error {
+		handler := &GetK8sEventsHandler{
+			ID:     c.Param("id"),
+			Name:   c.Param("name"),
+			NS:     c.Param("ns"),
+			Kind:   c.Param("kind"),
+			Object: c.Param("object"),
+		}
+		return handler.ServeHTTP(c)
+	})
+
+	webServerGroup.GET("/getK8spodLogs/:id/:name/:ns/:pod", func(c echo.Context) error {
+		handler := &GetK8sPodLogsHandler{
+			ID:    c.Param("id"),
+			Name:  c.Param("name"),
+			NS:    c.Param("ns"),
+			Pod:   c.Param("pod"),
+			Query: NewLogQuery(c),
+		}
+		return handler.ServeHTTP(c)
+	})
+
+	webServerGroup.GET("/getK8sworkloadLogs/:id/:name/:ns/:kind/:workload", func(c echo.Context) error {
+		handler := &GetK8sWorkloadLogsHandler{
+			ID:       c.Param("id"),
+			Name:     c.Param("name"),
+			NS:       c.Param("ns"),
+			Kind:     c.Param("kind"),
+			Workload: c.Param("workload"),
+			Query:    NewLogQuery(c),
+		}
+		return handler.ServeHTTP(c)
+	})
+
+	// Query: container, command (repeated for each argument), tty (default true), record
+	webServerGroup.GET("/execK8sPod/:id/:name/:ns/:pod", func(c echo.Context) error {
+		handler := &ExecK8sPodHandler{
+			ID:        c.Param("id"),
+			Name:      c.Param("name"),
+			NS:        c.Param("ns"),
+			Pod:       c.Param("pod"),
+			Container: c.QueryParam("container"),
+			Command:   c.QueryParams()["command"],
+			TTY:       c.QueryParam("tty") != "false",
+			Record:    c.QueryParam("record") == "true",
+		}
+		return handler.ServeHTTP(c)
+	}, RequireRole(RoleOperator))
+
+	webServerGroup.Any("/proxyK8s/:id/:name/:ns/:kind/:object/:port/*", func(c echo.Context) error {
+		handler := &ProxyK8sResourceHandler{
+			ID:     c.Param("id"),
+			Name:   c.Param("name"),
+			NS:     c.Param("ns"),
+			Kind:   c.Param("kind"),
+			Object: c.Param("object"),
+			Port:   c.Param("port"),
+			Path:   c.Param("*"),
+		}
+		return handler.ServeHTTP(c)
+	}, RequireRole(RoleOperator))
+
+	webServerGroup.GET("/portForwardK8sPod/:id/:name/:ns/:pod/:port", func(c echo.Context) error {
+		handler := &PortForwardK8sPodHandler{
+			ID:   c.Param("id"),
+			Name: c.Param("name"),
+			NS:   c.Param("ns"),
+			Pod:  c.Param("pod"),
+			Port: c.Param("port"),
+		}
+		return handler.ServeHTTP(c)
+	}, RequireRole(RoleOperator))
+
+	webServerGroup.GET("/getK8susageSeries/:id/:name/:ns/:kind/:object", func(c echo.Context) error {
+		handler := &GetK8sUsageSeriesHandler{
+			ID:     c.Param("id"),
+			Name:   c.Param("name"),
+			NS:     c.Param("ns"),
+			Kind:   c.Param("kind"),
+			Object: c.Param("object"),
+			From:   c.QueryParam("from"),
+			To:     c.QueryParam("to"),
+		}
+		return handler.ServeHTTP(c)
+	})
+
+	webServerGroup.GET("/getK8snodeUsageSeries/:id/:name/:node", func(c echo.Context) error {
+		handler := &GetK8sUsageSeriesHandler{
+			ID:     c.Param("id"),
+			Name:   c.Param("name"),
+			Kind:   "Node",
+			Object: c.Param("node"),
+			From:   c.QueryParam("from"),
+			To:     c.QueryParam("to"),
+		}
+		return handler.ServeHTTP(c)
+	})
+
+	webServerGroup.GET("/getK8srecommendations/:id/:name/:ns", func(c echo.Context) error {
+		handler := &GetK8sRecommendationsHandler{
+			ID:   c.Param("id"),
+			Name: c.Param("name"),
+			NS:   c.Param("ns"),
+			Days: c.QueryParam("days"),
+		}
+		return handler.ServeHTTP(c)
+	})
+
+	webServerGroup.GET("/watchK8s/:id/:name/:ns/:kind", func(c echo.Context) error {
+		handler := &WatchK8sResourcesHandler{
+			ID:              c.Param("id"),
+			Name:            c.Param("name"),
+			NS:              c.Param("ns"),
+			Kind:            c.Param("kind"),
+			Selector:        c.QueryParam("selector"),
+			ResourceVersion: c.QueryParam("resourceVersion"),
+		}
+		return handler.ServeHTTP(c)
+	})
+
+	webServerGroup.GET("/admin/informerCache", func(c echo.Context) error {
+		handler := &GetInformerCacheStatusHandler{}
+		return handler.ServeHTTP(c)
+	}, RequireRole(RoleAdmin))
+
 	webServerGroup.DELETE("/deleteKubeconfig/:id", func(c echo.Context) error {
 		handler := &DeleteKubeconfigHandler{
 			ID: c.Param("id"),
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/gorilla/websocket"
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	"io"
+	v1core "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// portForwardErrorWait bounds the wait for the error of the pod once it closed the data stream
+const portForwardErrorWait = 5 * time.Second
+
+// PortForwardK8sPodHandler forwards one TCP connection to a pod port over a WebSocket,
+// binary messages carry the raw bytes in both directions
+type PortForwardK8sPodHandler struct {
+	ID   string
+	Name string
+	NS   string
+	Pod  string
+	Port string
+}
+
+func (h *PortForwardK8sPodHandler) ServeHTTP(c echo.Context) error {
+	restConfig, errMsg, err := GetRestConfig(h.ID, h.Name, "PortForwardK8sPodHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "PortForwardK8sPodHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	pod, err := clientset.CoreV1().Pods(h.NS).Get(context.Background(), h.Pod, v1.GetOptions{})
+	if err != nil {
+		logger.Warnf("Failed to get pod when calling PortForwardK8sPodHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+	port, err := resolvePodPort(*pod, h.Port)
+	if err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+
+	transport, upgrader, err := spdy.RoundTripperFor(restConfig)
+	if err != nil {
+		logger.Warnf("Failed to create round tripper when calling PortForwardK8sPodHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+	req := clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(h.NS).
+		Name(h.Pod).
+		SubResource("portforward")
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, "POST", req.URL())
+	streamConn, _, err := dialer.Dial(portforward.PortForwardProtocolV1Name)
+	if err != nil {
+		logger.Warnf("Failed to dial pod when calling PortForwardK8sPodHandler: %v", err)
+		return c.NoContent(http.StatusBadGateway)
+	}
+	defer streamConn.Close()
+
+	headers := http.Header{}
+	headers.Set(v1core.StreamType, v1core.StreamTypeError)
+	headers.Set(v1core.PortHeader, strconv.Itoa(int(port)))
+	headers.Set(v1core.PortForwardRequestIDHeader, "0")
+	errorStream, err := streamConn.CreateStream(headers)
+	if err != nil {
+		logger.Warnf("Failed to create error stream when calling PortForwardK8sPodHandler: %v", err)
+		return c.NoContent(http.StatusBadGateway)
+	}
+	// The error stream is only read from
+	_ = errorStream.Close()
+
+	headers.Set(v1core.StreamType, v1core.StreamTypeData)
+	dataStream, err := streamConn.CreateStream(headers)
+	if err != nil {
+		logger.Warnf("Failed to create data stream when calling PortForwardK8sPodHandler: %v", err)
+		return c.NoContent(http.StatusBadGateway)
+	}
+
+	conn, err := wsUpgrader.Upgrade(c.Response(), c.Request(), nil)
+	if err != nil {
+		logger.Warnf("Failed to upgrade connection when calling PortForwardK8sPodHandler: %v", err)
+		return nil
+	}
+	defer conn.Close()
+
+	audit := StartAudit(c, "port-forward", AuditTarget{ClusterID: h.ID, ClusterName: h.Name, Namespace: h.NS, Kind: "Pod", Name: h.Pod}, map[string]string{
+		"port": strconv.Itoa(int(port)),
+	})
+
+	// The error stream is read alongside the data, the pod may keep it open after the client is gone
+	// and closing streamConn on return ends the read
+	errorDone := make(chan error, 1)
+	go func() {
+		message, err := io.ReadAll(errorStream)
+		if err != nil {
+			errorDone <- fmt.Errorf("error reading from error stream: %v", err)
+		} else if len(message) > 0 {
+			errorDone <- fmt.Errorf("an error occurred forwarding to port %d: %s", port, message)
+		} else {
+			errorDone <- nil
+		}
+	}()
+
+	var writeMu sync.Mutex
+	remoteDone := make(chan struct{})
+	go func() {
+		defer close(remoteDone)
+		buffer := make([]byte, 32*1024)
+		for {
+			n, err := dataStream.Read(buffer)
+			if n > 0 {
+				writeMu.Lock()
+				writeErr := conn.WriteMessage(websocket.BinaryMessage, buffer[:n])
+				writeMu.Unlock()
+				if writeErr != nil {
+					return
+				}
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	localDone := make(chan struct{})
+	go func() {
+		defer close(localDone)
+		// Tell the pod no more data is coming once the client is gone
+		defer dataStream.Close()
+		for {
+			messageType, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if messageType != websocket.BinaryMessage {
+				continue
+			}
+			if _, err := dataStream.Write(data); err != nil {
+				return
+			}
+		}
+	}()
+
+	var forwardErr error
+	select {
+	case <-remoteDone:
+		// The pod reports why it closed the data stream right after closing it
+		select {
+		case forwardErr = <-errorDone:
+		case <-time.After(portForwardErrorWait):
+		}
+	case <-localDone:
+		select {
+		case forwardErr = <-errorDone:
+		default:
+		}
+	}
+	audit.Finish(forwardErr)
+
+	writeMu.Lock()
+	defer writeMu.Unlock()
+	if forwardErr != nil {
+		_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseInternalServerErr, forwardErr.Error()))
+	} else {
+		_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	}
+	return nil
+}
+
+// resolvePodPort turns a port number or the name of a container port into the number to forward to
+func resolvePodPort(pod v1core.Pod, port string) (int32, error) {
+	if number, err := strconv.ParseInt(port, 10, 32); err == nil {
+		if number <= 0 || number > 65535 {
+			return 0, fmt.Errorf("invalid port %s", port)
+		}
+		return int32(number), nil
+	}
+	for _, container := range pod.Spec.Containers {
+		for _, containerPort := range container.Ports {
+			if containerPort.Name == port {
+				return containerPort.ContainerPort, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("pod %s has no port named %s", pod.Name, port)
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	"k8s.io/client-go/rest"
+	"net/http"
+	"net/http/httputil"
+	"strings"
+)
+
+// ProxyK8sResourceHandler forwards a request to a port of a pod or service through the proxy subresource of the API server.
+// Port is a number or port name, optionally prefixed with the scheme, e.g. https:8443.
+// Absolute links in proxied pages point outside of the proxy and are not rewritten.
+type ProxyK8sResourceHandler struct {
+	ID     string
+	Name   string
+	NS     string
+	Kind   string
+	Object string
+	Port   string
+	Path   string
+}
+
+var proxyResources = map[string]string{
+	"Pod":     "pods",
+	"Service": "services",
+}
+
+func (h *ProxyK8sResourceHandler) ServeHTTP(c echo.Context) error {
+	resource, ok := proxyResources[h.Kind]
+	if !ok {
+		return c.String(http.StatusBadRequest, "only Pod and Service can be proxied")
+	}
+
+	restConfig, errMsg, err := GetRestConfig(h.ID, h.Name, "ProxyK8sResourceHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "ProxyK8sResourceHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+	transport, err := rest.TransportFor(restConfig)
+	if err != nil {
+		logger.Warnf("Failed to create transport when calling ProxyK8sResourceHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	// The proxy subresource addresses a port as name:port or scheme:name:port
+	name := h.Object + ":" + h.Port
+	if scheme, port, found := strings.Cut(h.Port, ":"); found {
+		name = scheme + ":" + h.Object + ":" + port
+	}
+	target := clientset.CoreV1().RESTClient().Get().
+		Namespace(h.NS).
+		Resource(resource).
+		Name(name).
+		SubResource("proxy").
+		Suffix(h.Path).
+		URL()
+
+	login, _ := SessionUser(c)
+	logger.Infof("Proxying %s %s/%s/%s %s /%s for %q", c.Request().Method, h.NS, h.Kind, h.Object, h.Port, h.Path, login)
+
+	proxy := &httputil.ReverseProxy{
+		Transport: transport,
+		Director: func(req *http.Request) {
+			req.URL.Scheme = target.Scheme
+			req.URL.Host = target.Host
+			req.URL.Path = target.Path
+			req.URL.RawPath = ""
+			req.Host = target.Host
+			// Credentials of this server must not reach the pod, the transport adds the cluster credentials
+			req.Header.Del("Authorization")
+			req.Header.Del("Cookie")
+			// Let the transport negotiate compression so the gzip middleware does not encode twice
+			req.Header.Del("Accept-Encoding")
+		},
+		ModifyResponse: func(resp *http.Response) error {
+			resp.Header.Del("Set-Cookie")
+			// Pages of the pod are served on the origin of this server, the sandbox gives them an opaque origin
+			// so their scripts can not call the API with the session cookie
+			resp.Header.Set("Content-Security-Policy", "sandbox")
+			return nil
+		},
+		ErrorHandler: func(w http.ResponseWriter, req *http.Request, err error) {
+			logger.Warnf("Failed to proxy request when calling ProxyK8sResourceHandler: %v", err)
+			w.WriteHeader(http.StatusBadGateway)
+		},
+	}
+	proxy.ServeHTTP(c.Response(), c.Request())
+	return nil
+}
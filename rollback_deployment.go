@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"net/http"
+	"strconv"
+)
+
+// rollbackAnnotationsToSkip are deployment annotations kept as they are on rollback, all others are copied from the ReplicaSet
+var rollbackAnnotationsToSkip = map[string]bool{
+	"kubectl.kubernetes.io/last-applied-configuration": true,
+	RevisionAnnotation:                          true,
+	"deployment.kubernetes.io/revision-history": true,
+	"deployment.kubernetes.io/desired-replicas": true,
+	"deployment.kubernetes.io/max-replicas":     true,
+	"deprecated.deployment.rollback.to":         true,
+}
+
+type RollbackDeploymentHandler struct {
+	ID         string
+	Name       string
+	NS         string
+	Deployment string
+}
+
+// ServeHTTP rolls the deployment back to the pod template of a revision like kubectl rollout undo,
+// revision 0 means the previous one. The rollout is tracked in the activity console.
+func (h *RollbackDeploymentHandler) ServeHTTP(c echo.Context) error {
+	type rollbackType struct {
+		Revision int64 `json:"revision"`
+		DryRun   bool  `json:"dry_run"`
+	}
+	type Response struct {
+		From    DeploymentRevision `json:"from"`
+		To      DeploymentRevision `json:"to"`
+		Diff    string             `json:"diff"`
+		DryRun  bool               `json:"dry_run"`
+		Skipped bool               `json:"skipped"`
+	}
+	var rollbackPost rollbackType
+	if err := c.Bind(&rollbackPost); err != nil || rollbackPost.Revision < 0 {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "RollbackDeploymentHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	deployment, err := clientset.AppsV1().Deployments(h.NS).Get(context.Background(), h.Deployment, metav1.GetOptions{})
+	if err != nil {
+		logger.Warnf("Failed to get deployment when calling RollbackDeploymentHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+	if deployment.Spec.Paused {
+		return c.String(http.StatusConflict, "deployment is paused, resume it before rolling back")
+	}
+
+	replicaSets, err := DeploymentReplicaSets(clientset, deployment)
+	if err != nil {
+		logger.Warnf("Failed to get replica sets when calling RollbackDeploymentHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	currentRevision, _ := strconv.ParseInt(deployment.Annotations[RevisionAnnotation], 10, 64)
+	target, err := FindRevision(replicaSets, rollbackPost.Revision, currentRevision)
+	if err != nil {
+		return c.String(http.StatusNotFound, err.Error())
+	}
+	current, err := FindRevision(replicaSets, currentRevision, currentRevision)
+	if err != nil {
+		return c.String(http.StatusNotFound, err.Error())
+	}
+
+	diff, err := PodTemplateDiff(*current, *target)
+	if err != nil {
+		logger.Warnf("Failed to diff pod templates when calling RollbackDeploymentHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+	response := Response{
+		From:    NewDeploymentRevision(*current, currentRevision),
+		To:      NewDeploymentRevision(*target, currentRevision),
+		Diff:    diff,
+		DryRun:  rollbackPost.DryRun,
+		Skipped: diff == "",
+	}
+	if response.Skipped {
+		// The deployment already runs the template of the revision
+		return c.JSON(http.StatusOK, response)
+	}
+
+	template := *target.Spec.Template.DeepCopy()
+	delete(template.Labels, PodTemplateHashLabel)
+	annotations := make(map[string]string)
+	for key, value := range deployment.Annotations {
+		if rollbackAnnotationsToSkip[key] {
+			annotations[key] = value
+		}
+	}
+	for key, value := range target.Annotations {
+		if !rollbackAnnotationsToSkip[key] {
+			annotations[key] = value
+		}
+	}
+	patch, err := json.Marshal([]map[string]interface{}{
+		{"op": "replace", "path": "/spec/template", "value": template},
+		{"op": "replace", "path": "/metadata/annotations", "value": annotations},
+	})
+	if err != nil {
+		logger.Warnf("Failed to marshal patch when calling RollbackDeploymentHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	patchOptions := metav1.PatchOptions{}
+	if rollbackPost.DryRun {
+		patchOptions.DryRun = []string{metav1.DryRunAll}
+	}
+	toRevision := strconv.FormatInt(ReplicaSetRevision(*target), 10)
+	var audit *AuditEntry
+	if !rollbackPost.DryRun {
+		audit = StartAudit(c, "rollback", AuditTarget{ClusterID: h.ID, ClusterName: h.Name, Namespace: h.NS, Kind: "Deployment", Name: h.Deployment}, map[string]string{
+			"from_revision": strconv.FormatInt(currentRevision, 10),
+			"to_revision":   toRevision,
+		})
+	}
+	if _, err := clientset.AppsV1().Deployments(h.NS).Patch(context.Background(), h.Deployment, types.JSONPatchType, patch, patchOptions); err != nil {
+		logger.Warnf("Failed to patch deployment when calling RollbackDeploymentHandler: %v", err)
+		if audit != nil {
+			audit.Finish(err)
+		}
+		return c.String(http.StatusInternalServerError, err.Error())
+	}
+	if rollbackPost.DryRun {
+		return c.JSON(http.StatusOK, response)
+	}
+
+	operation := NewActivityOperation(RolloutActivityType, fmt.Sprintf("Rollback of Deployment %s/%s", h.NS, h.Deployment), audit.Target)
+	operation.Log(ActivityRequested, fmt.Sprintf("rolling back from revision %d to revision %s", currentRevision, toRevision))
+	TrackRollout(clientset, operation, audit)
+
+	return c.JSON(http.StatusAccepted, response)
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"net/http"
+	"time"
+)
+
+const RestartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+// RolloutK8sResourceHandler runs kubectl rollout restart, pause and resume,
+// restart works for Deployments, StatefulSets and DaemonSets and pause/resume for Deployments
+type RolloutK8sResourceHandler struct {
+	ID     string
+	Name   string
+	NS     string
+	Kind   string
+	Object string
+	Action string
+}
+
+type rolloutPatchFunc func(ctx context.Context, clientset *kubernetes.Clientset, ns, name string, patch []byte) error
+
+var rolloutPatchFuncs = map[string]rolloutPatchFunc{
+	"Deployment": func(ctx context.Context, clientset *kubernetes.Clientset, ns, name string, patch []byte) error {
+		_, err := clientset.AppsV1().Deployments(ns).Patch(ctx, name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+		return err
+	},
+	"StatefulSet": func(ctx context.Context, clientset *kubernetes.Clientset, ns, name string, patch []byte) error {
+		_, err := clientset.AppsV1().StatefulSets(ns).Patch(ctx, name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+		return err
+	},
+	"DaemonSet": func(ctx context.Context, clientset *kubernetes.Clientset, ns, name string, patch []byte) error {
+		_, err := clientset.AppsV1().DaemonSets(ns).Patch(ctx, name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+		return err
+	},
+}
+
+func (h *RolloutK8sResourceHandler) ServeHTTP(c echo.Context) error {
+	patchFunc, ok := rolloutPatchFuncs[h.Kind]
+	if !ok {
+		return c.String(http.StatusBadRequest, "only Deployment, StatefulSet and DaemonSet support rollout actions")
+	}
+
+	var spec map[string]interface{}
+	switch h.Action {
+	case "restart":
+		spec = map[string]interface{}{
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"annotations": map[string]string{RestartedAtAnnotation: time.Now().Format(time.RFC3339)},
+				},
+			},
+		}
+	case "pause", "resume":
+		if h.Kind != "Deployment" {
+			return c.String(http.StatusBadRequest, "only Deployments can be paused and resumed")
+		}
+		spec = map[string]interface{}{"paused": h.Action == "pause"}
+	default:
+		return c.String(http.StatusBadRequest, "unsupported rollout action "+h.Action)
+	}
+	patch, err := json.Marshal(map[string]interface{}{"spec": spec})
+	if err != nil {
+		logger.Warnf("Failed to marshal patch when calling RolloutK8sResourceHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "RolloutK8sResourceHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	if h.Kind == "Deployment" && h.Action == "restart" {
+		deployment, err := clientset.AppsV1().Deployments(h.NS).Get(context.Background(), h.Object, metav1.GetOptions{})
+		if err != nil {
+			logger.Warnf("Failed to get deployment when calling RolloutK8sResourceHandler: %v", err)
+			return c.NoContent(http.StatusInternalServerError)
+		}
+		if deployment.Spec.Paused {
+			return c.String(http.StatusConflict, "deployment is paused, resume it before restarting")
+		}
+	}
+
+	target := AuditTarget{ClusterID: h.ID, ClusterName: h.Name, Namespace: h.NS, Kind: h.Kind, Name: h.Object}
+	audit := StartAudit(c, "rollout-"+h.Action, target, nil)
+	operation := NewActivityOperation(RolloutActivityType, fmt.Sprintf("Rollout %s of %s %s/%s", h.Action, h.Kind, h.NS, h.Object), target)
+
+	if err := patchFunc(context.Background(), clientset, h.NS, h.Object, patch); err != nil {
+		logger.Warnf("Failed to patch %s when calling RolloutK8sResourceHandler: %v", h.Kind, err)
+		operation.Log(ActivityFailed, err.Error())
+		audit.Finish(err)
+		return c.String(http.StatusInternalServerError, err.Error())
+	}
+
+	operation.Log(ActivityRequested, h.Action+" requested")
+	TrackRollout(clientset, operation, audit)
+
+	return c.NoContent(http.StatusAccepted)
+}
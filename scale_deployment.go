@@ -1,10 +1,15 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/retry"
 	"net/http"
 	"strconv"
-	"time"
 )
 
 type ScaleDeploymentHandler struct {
@@ -14,33 +19,75 @@ type ScaleDeploymentHandler struct {
 	Deployment string
 }
 
+// ServeHTTP scales through the scale subresource and tracks the deployment in the activity console
+// until the available replicas match
 func (h *ScaleDeploymentHandler) ServeHTTP(c echo.Context) error {
 	type scaleType struct {
-		Scale int `json:"scale"`
+		Scale int32 `json:"scale"`
+	}
+	// Current is the number of replicas observed before scaling, like the current of GetK8sScaleHandler
+	type Response struct {
+		Current int32  `json:"current"`
+		Desired int32  `json:"desired"`
+		Warning string `json:"warning"`
 	}
 	var scalePost scaleType
 	if err := c.Bind(&scalePost); err != nil {
 		return c.NoContent(http.StatusBadRequest)
 	}
-	//clientset, errMsg, err := GetClientSet(h.ID, h.Name, "ScaleDeploymentHandler")
-	//if err != nil {
-	//	logger.Warnf("%s: %v", errMsg, err)
-	//	return c.NoContent(http.StatusInternalServerError)
-	//}
+	if scalePost.Scale < 0 {
+		return c.String(http.StatusBadRequest, "replicas must not be negative")
+	}
 
-	//patch := []byte(fmt.Sprintf(`{"spec":{"replicas":%d}}`, scalePost.Scale))
-	//_, err = clientset.AppsV1().Deployments(h.NS).Patch(context.TODO(), h.Deployment, types.StrategicMergePatchType, patch, v1.PatchOptions{})
-	//if err != nil {
-	//	panic(err.Error())
-	//}
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "ScaleDeploymentHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	hpa, err := FindHPA(clientset, h.NS, schema.GroupKind{Group: "apps", Kind: "Deployment"}, h.Deployment)
+	if err != nil {
+		logger.Warnf("Failed to get horizontal pod autoscalers when calling ScaleDeploymentHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+	if err := ValidateHPAReplicas(hpa, scalePost.Scale); err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
 
-	go func() {
-		for range 10 {
-			LogActivityConsoleAdd("Deployment "+h.Deployment+" by "+strconv.Itoa(scalePost.Scale), "Scaling")
+	target := AuditTarget{ClusterID: h.ID, ClusterName: h.Name, Namespace: h.NS, Kind: "Deployment", Name: h.Deployment}
+	audit := StartAudit(c, "scale", target, map[string]string{
+		"replicas": strconv.Itoa(int(scalePost.Scale)),
+	})
+	operation := NewActivityOperation(ScaleActivityType, fmt.Sprintf("Scale of Deployment %s/%s", h.NS, h.Deployment), target)
+	operation.Log(ActivityRequested, fmt.Sprintf("scaling to %d replicas", scalePost.Scale))
 
-			time.Sleep(2 * time.Second)
+	var current int32
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		scale, err := clientset.AppsV1().Deployments(h.NS).GetScale(context.Background(), h.Deployment, metav1.GetOptions{})
+		if err != nil {
+			return err
 		}
-	}()
+		current = scale.Status.Replicas
+		scale.Spec.Replicas = scalePost.Scale
+		_, err = clientset.AppsV1().Deployments(h.NS).UpdateScale(context.Background(), h.Deployment, scale, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		logger.Warnf("Failed to scale deployment when calling ScaleDeploymentHandler: %v", err)
+		operation.Log(ActivityFailed, err.Error())
+		audit.Finish(err)
+		return c.String(http.StatusInternalServerError, err.Error())
+	}
+
+	response := Response{
+		Current: current,
+		Desired: scalePost.Scale,
+		Warning: HPAWarning(hpa),
+	}
+	if response.Warning != "" {
+		operation.Log(ActivityProgressing, response.Warning)
+	}
+	TrackRollout(clientset, operation, audit)
 
-	return c.NoContent(http.StatusOK)
+	return c.JSON(http.StatusAccepted, response)
 }
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"net/http"
+	"strconv"
+)
+
+// ScaleK8sResourceHandler scales any workload serving the scale subresource, Kind is a built-in kind
+// like StatefulSet or Kind.group for custom resources
+type ScaleK8sResourceHandler struct {
+	ID     string
+	Name   string
+	NS     string
+	Kind   string
+	Object string
+}
+
+func (h *ScaleK8sResourceHandler) ServeHTTP(c echo.Context) error {
+	type scaleType struct {
+		Scale int32 `json:"scale"`
+	}
+	var scalePost scaleType
+	if err := c.Bind(&scalePost); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	if scalePost.Scale < 0 {
+		return c.String(http.StatusBadRequest, "replicas must not be negative")
+	}
+
+	config, errMsg, err := GetRestConfig(h.ID, h.Name, "ScaleK8sResourceHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "ScaleK8sResourceHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	scaleClient, err := NewScaleClient(config, clientset)
+	if err != nil {
+		logger.Warnf("Failed to create scale client when calling ScaleK8sResourceHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+	resource, groupKind, err := scaleClient.Resource(h.Kind)
+	if err != nil {
+		logger.Warnf("Failed to resolve kind %s when calling ScaleK8sResourceHandler: %v", h.Kind, err)
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+
+	hpa, err := FindHPA(clientset, h.NS, groupKind, h.Object)
+	if err != nil {
+		logger.Warnf("Failed to get horizontal pod autoscalers when calling ScaleK8sResourceHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+	if err := ValidateHPAReplicas(hpa, scalePost.Scale); err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+
+	target := AuditTarget{ClusterID: h.ID, ClusterName: h.Name, Namespace: h.NS, Kind: groupKind.Kind, Name: h.Object}
+	audit := StartAudit(c, "scale", target, map[string]string{
+		"resource": resource.String(),
+		"replicas": strconv.Itoa(int(scalePost.Scale)),
+	})
+	operation := NewActivityOperation(ScaleActivityType, fmt.Sprintf("Scale of %s %s/%s", groupKind.Kind, h.NS, h.Object), target)
+	operation.Log(ActivityRequested, fmt.Sprintf("scaling to %d replicas", scalePost.Scale))
+
+	var current int32
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		scale, err := scaleClient.Scales(h.NS).Get(context.Background(), resource, h.Object, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		current = scale.Status.Replicas
+		scale.Spec.Replicas = scalePost.Scale
+		_, err = scaleClient.Scales(h.NS).Update(context.Background(), resource, scale, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		logger.Warnf("Failed to scale %s when calling ScaleK8sResourceHandler: %v", groupKind.Kind, err)
+		operation.Log(ActivityFailed, err.Error())
+		audit.Finish(err)
+		return c.String(http.StatusInternalServerError, err.Error())
+	}
+
+	response := ScaleResponse{
+		Kind:    groupKind.Kind,
+		Current: current,
+		Desired: scalePost.Scale,
+		Warning: HPAWarning(hpa),
+	}
+	if response.Warning != "" {
+		operation.Log(ActivityProgressing, response.Warning)
+	}
+	// Only built-in kinds have a known status to wait for
+	if groupKind.Group == scalableKindGroups[groupKind.Kind] && RolloutTrackable(groupKind.Kind) {
+		TrackRollout(clientset, operation, audit)
+	} else {
+		operation.Log(ActivityCompleted, "scale subresource updated")
+		audit.Finish(nil)
+	}
+
+	return c.JSON(http.StatusAccepted, response)
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"github.com/labstack/echo-contrib/session"
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	"golang.org/x/crypto/bcrypt"
+	"net/http"
+)
+
+// LoginHandler checks the credentials against the configured users and stores the login and role in the session
+type LoginHandler struct{}
+
+func (h *LoginHandler) ServeHTTP(c echo.Context) error {
+	type loginType struct {
+		Login    string `json:"login"`
+		Password string `json:"password"`
+	}
+	type Response struct {
+		Login string `json:"login"`
+		Role  string `json:"role"`
+	}
+	var loginPost loginType
+	if err := c.Bind(&loginPost); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	user, ok := SecurityUsers[loginPost.Login]
+	if !ok || bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(loginPost.Password)) != nil {
+		logger.Warnf("Failed login of %q", loginPost.Login)
+		return c.NoContent(http.StatusUnauthorized)
+	}
+
+	// A stale cookie only fails loading the old session, the login starts a new one anyway
+	sess, err := session.Get(HttpSessionName, c)
+	if sess == nil {
+		logger.Warnf("Failed to get session when calling LoginHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+	// A new session ID on login, a session ID known before the login must not gain the role
+	if store, ok := sess.Store().(*HttpSessionMongoDB); ok && err == nil && sess.ID != "" {
+		_ = store.Delete(sess)
+	}
+	sess.ID = ""
+	sess.Values = make(map[interface{}]interface{})
+	sess.Values["login"] = user.Login
+	sess.Values["role"] = user.Role
+	sess.Values["admin"] = user.Role == RoleAdmin
+	if err := sess.Save(c.Request(), c.Response()); err != nil {
+		logger.Warnf("Failed to save session when calling LoginHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	logger.Infof("User %q logged in with role %s", user.Login, user.Role)
+	return c.JSON(http.StatusOK, Response{
+		Login: user.Login,
+		Role:  user.Role,
+	})
+}
+
+// LogoutHandler drops the session, the next request starts an anonymous session with the default role
+type LogoutHandler struct{}
+
+func (h *LogoutHandler) ServeHTTP(c echo.Context) error {
+	sess, err := session.Get(HttpSessionName, c)
+	if err != nil {
+		// Nothing to drop for a missing or expired session
+		return c.NoContent(http.StatusOK)
+	}
+	if store, ok := sess.Store().(*HttpSessionMongoDB); ok && sess.ID != "" {
+		if err := store.Delete(sess); err != nil {
+			logger.Warnf("Failed to delete session when calling LogoutHandler: %v", err)
+			return c.NoContent(http.StatusInternalServerError)
+		}
+	}
+	return c.NoContent(http.StatusOK)
+}
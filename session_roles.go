@@ -0,0 +1,54 @@
+package main
+
+import (
+	"github.com/labstack/echo-contrib/session"
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	"net/http"
+)
+
+const (
+	RoleViewer   = "viewer"
+	RoleOperator = "operator"
+	RoleAdmin    = "admin"
+)
+
+var roleRanks = map[string]int{
+	RoleViewer:   0,
+	RoleOperator: 1,
+	RoleAdmin:    2,
+}
+
+// SessionUser returns the login and role of the session set by LoginHandler, sessions without a role get the configured default role
+// and sessions of an admin are always admin
+func SessionUser(c echo.Context) (login string, role string) {
+	role = DefaultRole
+	sess, err := session.Get(HttpSessionName, c)
+	if err != nil {
+		return "", role
+	}
+	login, _ = sess.Values["login"].(string)
+	if sessionRole, ok := sess.Values["role"].(string); ok {
+		if _, known := roleRanks[sessionRole]; known {
+			role = sessionRole
+		}
+	}
+	if admin, ok := sess.Values["admin"].(bool); ok && admin {
+		role = RoleAdmin
+	}
+	return login, role
+}
+
+// RequireRole rejects requests of sessions whose role ranks below the given one
+func RequireRole(role string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			login, sessionRole := SessionUser(c)
+			if roleRanks[sessionRole] < roleRanks[role] {
+				logger.Warnf("Denied %s %s to %q with role %s, requires %s", c.Request().Method, c.Path(), login, sessionRole, role)
+				return c.NoContent(http.StatusForbidden)
+			}
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"net/http"
+	"strconv"
+)
+
+const CronJobActivityType = "CronJob"
+
+type SuspendCronJobHandler struct {
+	ID      string
+	Name    string
+	NS      string
+	CronJob string
+}
+
+// ServeHTTP suspends or resumes the schedule of a cron job, jobs already running are not affected
+func (h *SuspendCronJobHandler) ServeHTTP(c echo.Context) error {
+	type suspendType struct {
+		Suspend bool `json:"suspend"`
+	}
+	var suspendPost suspendType
+	if err := c.Bind(&suspendPost); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "SuspendCronJobHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	action := "resume"
+	if suspendPost.Suspend {
+		action = "suspend"
+	}
+	target := AuditTarget{ClusterID: h.ID, ClusterName: h.Name, Namespace: h.NS, Kind: "CronJob", Name: h.CronJob}
+	audit := StartAudit(c, "cronjob-"+action, target, nil)
+
+	patch := []byte(fmt.Sprintf(`{"spec":{"suspend":%s}}`, strconv.FormatBool(suspendPost.Suspend)))
+	cronJob, err := clientset.BatchV1().CronJobs(h.NS).Patch(context.Background(), h.CronJob, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	audit.Finish(err)
+	if err != nil {
+		logger.Warnf("Failed to patch cron job when calling SuspendCronJobHandler: %v", err)
+		return c.String(http.StatusInternalServerError, err.Error())
+	}
+
+	if suspendPost.Suspend {
+		LogActivityConsoleAdd(fmt.Sprintf("CronJob %s/%s suspended", h.NS, h.CronJob), CronJobActivityType)
+	} else {
+		LogActivityConsoleAdd(fmt.Sprintf("CronJob %s/%s resumed", h.NS, h.CronJob), CronJobActivityType)
+	}
+
+	return c.JSON(http.StatusOK, NewCronJobRow(*cronJob))
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	v1batch "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
+	"strings"
+)
+
+type TriggerCronJobHandler struct {
+	ID      string
+	Name    string
+	NS      string
+	CronJob string
+}
+
+// ServeHTTP creates a job from the job template of a cron job like kubectl create job --from=cronjob/<name>
+func (h *TriggerCronJobHandler) ServeHTTP(c echo.Context) error {
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "TriggerCronJobHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	cronJob, err := clientset.BatchV1().CronJobs(h.NS).Get(context.Background(), h.CronJob, metav1.GetOptions{})
+	if err != nil {
+		logger.Warnf("Failed to get cron job when calling TriggerCronJobHandler: %v", err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	job := NewManualJob(cronJob)
+	target := AuditTarget{ClusterID: h.ID, ClusterName: h.Name, Namespace: h.NS, Kind: "CronJob", Name: h.CronJob}
+	audit := StartAudit(c, "cronjob-trigger", target, map[string]string{"job": job.Name})
+
+	created, err := clientset.BatchV1().Jobs(h.NS).Create(context.Background(), job, metav1.CreateOptions{})
+	audit.Finish(err)
+	if err != nil {
+		logger.Warnf("Failed to create job when calling TriggerCronJobHandler: %v", err)
+		return c.String(http.StatusInternalServerError, err.Error())
+	}
+
+	LogActivityConsoleAdd(fmt.Sprintf("CronJob %s/%s triggered manually, created job %s", h.NS, h.CronJob, created.Name), CronJobActivityType)
+
+	return c.JSON(http.StatusCreated, NewJobRow(*created))
+}
+
+// NewManualJob builds a job owned by the cron job, the name keeps room for the
+// suffix within the 63 characters allowed in the job-name label
+func NewManualJob(cronJob *v1batch.CronJob) *v1batch.Job {
+	base := cronJob.Name
+	if len(base) > 48 {
+		base = base[:48]
+	}
+	base = strings.TrimRight(base, "-.")
+
+	annotations := map[string]string{"cronjob.kubernetes.io/instantiate": "manual"}
+	for key, value := range cronJob.Spec.JobTemplate.Annotations {
+		annotations[key] = value
+	}
+	labels := make(map[string]string)
+	for key, value := range cronJob.Spec.JobTemplate.Labels {
+		labels[key] = value
+	}
+
+	return &v1batch.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            base + "-manual-" + strings.ToLower(GenerateRandomString(5)),
+			Namespace:       cronJob.Namespace,
+			Annotations:     annotations,
+			Labels:          labels,
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(cronJob, v1batch.SchemeGroupVersion.WithKind("CronJob"))},
+		},
+		Spec: *cronJob.Spec.JobTemplate.Spec.DeepCopy(),
+	}
+}
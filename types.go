@@ -8,6 +8,10 @@ import (
 type Config struct {
 	Database DatabaseConfig `toml:"database" json:"database"`
 	Log      LogConfig      `toml:"log" json:"log"`
+	Cache    CacheConfig    `toml:"cache" json:"cache"`
+	Security SecurityConfig `toml:"security" json:"security"`
+	Usage    UsageConfig    `toml:"usage" json:"usage"`
+	Quota    QuotaConfig    `toml:"quota" json:"quota"`
 }
 
 type DatabaseConfig struct {
@@ -36,6 +40,35 @@ type LogConfig struct {
 	Compress   bool `toml:"compress" json:"compress"`
 }
 
+type CacheConfig struct {
+	Enabled            bool `toml:"enabled" json:"enabled"`
+	IdleTimeoutMinutes int  `toml:"idle_timeout_minutes" json:"idle_timeout_minutes"`
+	ResyncMinutes      int  `toml:"resync_minutes" json:"resync_minutes"`
+}
+
+type SecurityConfig struct {
+	DefaultRole string       `toml:"default_role" json:"default_role"`
+	Users       []UserConfig `toml:"users" json:"users"`
+}
+
+type UserConfig struct {
+	Login        string `toml:"login" json:"login"`
+	PasswordHash string `toml:"password_hash" json:"-"`
+	Role         string `toml:"role" json:"role"`
+}
+
+type UsageConfig struct {
+	Enabled         bool `toml:"enabled" json:"enabled"`
+	IntervalSeconds int  `toml:"interval_seconds" json:"interval_seconds"`
+	RetentionDays   int  `toml:"retention_days" json:"retention_days"`
+	MaxPoints       int  `toml:"max_points" json:"max_points"`
+}
+
+type QuotaConfig struct {
+	Enabled         bool `toml:"enabled" json:"enabled"`
+	IntervalMinutes int  `toml:"interval_minutes" json:"interval_minutes"`
+}
+
 type DataSecureSessionKey struct {
 	SecureSessionKey []byte `bson:"secure_session_key"`
 }
@@ -67,4 +100,13 @@ type LogActivity struct {
 	Time    time.Time `json:"time"`
 	Type    string    `json:"type"`
 	Message string    `json:"message"`
+	// Set for entries of long running operations, see ActivityOperation
+	OperationID string       `bson:"operation_id,omitempty" json:"operation_id,omitempty"`
+	State       string       `bson:"state,omitempty" json:"state,omitempty"`
+	Target      *AuditTarget `bson:"target,omitempty" json:"target,omitempty"`
+}
+
+type RowCondition struct {
+	OK      bool   `json:"ok"`
+	Message string `json:"message"`
 }
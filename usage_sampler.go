@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"errors"
+	logger "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"time"
+)
+
+type UsageSampleMeta struct {
+	ClusterID string `bson:"cluster_id"`
+	Cluster   string `bson:"cluster"`
+	Kind      string `bson:"kind"`
+	Namespace string `bson:"namespace"`
+	Name      string `bson:"name"`
+	// Container is only set for samples of kind Container, which are recorded next to the sample of their pod
+	Container string `bson:"container"`
+	// The workload a pod belongs to, pods of Deployments are recorded with the Deployment rather than the ReplicaSet
+	OwnerKind string `bson:"owner_kind"`
+	OwnerName string `bson:"owner_name"`
+}
+
+type UsageSample struct {
+	Time time.Time       `bson:"time"`
+	Meta UsageSampleMeta `bson:"meta"`
+	// CPU in millicores and memory in bytes
+	CPU    int64 `bson:"cpu"`
+	Memory int64 `bson:"memory"`
+}
+
+type UsageSampler struct {
+	interval  time.Duration
+	retention time.Duration
+}
+
+// StartUsageSampler creates the time-series collection and starts sampling every cluster in the background
+func StartUsageSampler(config UsageConfig) {
+	if !config.Enabled {
+		return
+	}
+	s := &UsageSampler{
+		interval:  time.Duration(config.IntervalSeconds) * time.Second,
+		retention: time.Duration(config.RetentionDays) * 24 * time.Hour,
+	}
+	if err := s.createCollection(); err != nil {
+		logger.Warnf("Failed to create usage samples collection, usage history is disabled: %v", err)
+		return
+	}
+	go s.run()
+}
+
+func (s *UsageSampler) createCollection() error {
+	granularity := "seconds"
+	if s.interval >= time.Minute {
+		granularity = "minutes"
+	}
+	opts := options.CreateCollection().
+		SetTimeSeriesOptions(options.TimeSeries().SetTimeField("time").SetMetaField("meta").SetGranularity(granularity)).
+		SetExpireAfterSeconds(int64(s.retention.Seconds()))
+	err := DBHelper.db.CreateCollection(context.Background(), UsageSamples, opts)
+	var commandErr mongo.CommandError
+	if errors.As(err, &commandErr) && commandErr.Name == "NamespaceExists" {
+		// Keep the retention in line with the config when it changed since the collection was created
+		return DBHelper.db.RunCommand(context.Background(), bson.D{
+			{Key: "collMod", Value: UsageSamples},
+			{Key: "expireAfterSeconds", Value: int64(s.retention.Seconds())},
+		}).Err()
+	}
+	return err
+}
+
+func (s *UsageSampler) run() {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for now := range ticker.C {
+		clusters, err := ListClusters()
+		if err != nil {
+			logger.Warnf("Failed to list clusters for usage sampling: %v", err)
+			continue
+		}
+		for _, cluster := range clusters {
+			if err := s.sample(cluster, now); err != nil {
+				logger.Debugf("Failed to sample usage of cluster %s: %v", cluster.Name, err)
+			}
+		}
+	}
+}
+
+func (s *UsageSampler) sample(cluster ClusterRef, now time.Time) error {
+	clientset, _, err := GetClientSet(cluster.ID, cluster.Name, "UsageSampler")
+	if err != nil {
+		return err
+	}
+	metricsClient, _, err := GetMetricsClientSet(cluster.ID, cluster.Name, "UsageSampler")
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), s.interval)
+	defer cancel()
+
+	podMetrics, err := metricsClient.MetricsV1beta1().PodMetricses(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	nodeMetrics, err := metricsClient.MetricsV1beta1().NodeMetricses().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	pods, err := clientset.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	replicaSets, err := clientset.AppsV1().ReplicaSets(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	replicaSetOwners := make(map[string]*metav1.OwnerReference)
+	for i := range replicaSets.Items {
+		replicaSetOwners[replicaSets.Items[i].Namespace+"/"+replicaSets.Items[i].Name] = metav1.GetControllerOf(&replicaSets.Items[i])
+	}
+	podOwners := make(map[string]*metav1.OwnerReference)
+	for i := range pods.Items {
+		owner := metav1.GetControllerOf(&pods.Items[i])
+		if owner != nil && owner.Kind == "ReplicaSet" {
+			if deployment := replicaSetOwners[pods.Items[i].Namespace+"/"+owner.Name]; deployment != nil {
+				owner = deployment
+			}
+		}
+		podOwners[pods.Items[i].Namespace+"/"+pods.Items[i].Name] = owner
+	}
+
+	samples := make([]interface{}, 0, len(podMetrics.Items)+len(nodeMetrics.Items))
+	for _, podMetric := range podMetrics.Items {
+		usage := v1.ResourceList{}
+		for _, container := range podMetric.Containers {
+			addResourceList(usage, container.Usage)
+		}
+		meta := UsageSampleMeta{
+			ClusterID: cluster.ID,
+			Cluster:   cluster.Name,
+			Kind:      "Pod",
+			Namespace: podMetric.Namespace,
+			Name:      podMetric.Name,
+		}
+		if owner := podOwners[podMetric.Namespace+"/"+podMetric.Name]; owner != nil {
+			meta.OwnerKind = owner.Kind
+			meta.OwnerName = owner.Name
+		}
+		samples = append(samples, newUsageSample(now, meta, usage))
+		for _, container := range podMetric.Containers {
+			containerMeta := meta
+			containerMeta.Kind = "Container"
+			containerMeta.Container = container.Name
+			samples = append(samples, newUsageSample(now, containerMeta, container.Usage))
+		}
+	}
+	for _, nodeMetric := range nodeMetrics.Items {
+		meta := UsageSampleMeta{
+			ClusterID: cluster.ID,
+			Cluster:   cluster.Name,
+			Kind:      "Node",
+			Name:      nodeMetric.Name,
+		}
+		samples = append(samples, newUsageSample(now, meta, nodeMetric.Usage))
+	}
+	if len(samples) == 0 {
+		return nil
+	}
+	return DBHelper.InsertMany(UsageSamples, samples)
+}
+
+func newUsageSample(now time.Time, meta UsageSampleMeta, usage v1.ResourceList) UsageSample {
+	cpu := usage[v1.ResourceCPU]
+	memory := usage[v1.ResourceMemory]
+	return UsageSample{
+		Time:   now,
+		Meta:   meta,
+		CPU:    cpu.MilliValue(),
+		Memory: memory.Value(),
+	}
+}
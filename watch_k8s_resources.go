@@ -0,0 +1,395 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	logger "github.com/sirupsen/logrus"
+	v1apps "k8s.io/api/apps/v1"
+	v1batch "k8s.io/api/batch/v1"
+	v1core "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"net/http"
+	"time"
+)
+
+const (
+	WatchHeartbeatInterval = 15 * time.Second
+	// Watches closing right after they started are restarted with an exponential backoff
+	WatchRestartBackoff    = time.Second
+	WatchRestartMaxBackoff = 30 * time.Second
+)
+
+type WatchK8sResourcesHandler struct {
+	ID              string
+	Name            string
+	NS              string
+	Kind            string
+	Selector        string
+	ResourceVersion string
+}
+
+// WatchEvent carries the namespace, name and UID of the object, row IDs are generated for every row
+// and do not identify the object across events
+type WatchEvent struct {
+	Type            string      `json:"type"`
+	ResourceVersion string      `json:"resource_version"`
+	Namespace       string      `json:"namespace"`
+	Name            string      `json:"name"`
+	UID             string      `json:"uid"`
+	Row             interface{} `json:"row"`
+}
+
+// WatchResync replaces every row the client has, it is sent instead of the objects as ADDED events
+// when the watch has to start over from a fresh list
+type WatchResync struct {
+	ResourceVersion string       `json:"resource_version"`
+	Items           []WatchEvent `json:"items"`
+}
+
+type watchFunc func(ctx context.Context, clientset *kubernetes.Clientset, ns string, opts metav1.ListOptions) (watch.Interface, error)
+
+type listFunc func(ctx context.Context, clientset *kubernetes.Clientset, ns string, opts metav1.ListOptions) (runtime.Object, error)
+
+// watchKinds maps a kind to the watch it opens, the list a watch resumes from and to the row builder
+// of its list handler, so streamed rows have the same shape as the rows returned by the getK8s* endpoints
+var watchKinds = map[string]struct {
+	watch watchFunc
+	list  listFunc
+	row   func(obj runtime.Object) (interface{}, bool)
+}{
+	"Deployment": {
+		watch: func(ctx context.Context, clientset *kubernetes.Clientset, ns string, opts metav1.ListOptions) (watch.Interface, error) {
+			return clientset.AppsV1().Deployments(ns).Watch(ctx, opts)
+		},
+		list: func(ctx context.Context, clientset *kubernetes.Clientset, ns string, opts metav1.ListOptions) (runtime.Object, error) {
+			return clientset.AppsV1().Deployments(ns).List(ctx, opts)
+		},
+		row: func(obj runtime.Object) (interface{}, bool) {
+			deployment, ok := obj.(*v1apps.Deployment)
+			if !ok {
+				return nil, false
+			}
+			return NewDeploymentRow(*deployment), true
+		},
+	},
+	"ReplicaSet": {
+		watch: func(ctx context.Context, clientset *kubernetes.Clientset, ns string, opts metav1.ListOptions) (watch.Interface, error) {
+			return clientset.AppsV1().ReplicaSets(ns).Watch(ctx, opts)
+		},
+		list: func(ctx context.Context, clientset *kubernetes.Clientset, ns string, opts metav1.ListOptions) (runtime.Object, error) {
+			return clientset.AppsV1().ReplicaSets(ns).List(ctx, opts)
+		},
+		row: func(obj runtime.Object) (interface{}, bool) {
+			rs, ok := obj.(*v1apps.ReplicaSet)
+			if !ok {
+				return nil, false
+			}
+			return NewReplicaSetRow(*rs), true
+		},
+	},
+	"StatefulSet": {
+		watch: func(ctx context.Context, clientset *kubernetes.Clientset, ns string, opts metav1.ListOptions) (watch.Interface, error) {
+			return clientset.AppsV1().StatefulSets(ns).Watch(ctx, opts)
+		},
+		list: func(ctx context.Context, clientset *kubernetes.Clientset, ns string, opts metav1.ListOptions) (runtime.Object, error) {
+			return clientset.AppsV1().StatefulSets(ns).List(ctx, opts)
+		},
+		row: func(obj runtime.Object) (interface{}, bool) {
+			ss, ok := obj.(*v1apps.StatefulSet)
+			if !ok {
+				return nil, false
+			}
+			return NewStatefulSetRow(*ss), true
+		},
+	},
+	"DaemonSet": {
+		watch: func(ctx context.Context, clientset *kubernetes.Clientset, ns string, opts metav1.ListOptions) (watch.Interface, error) {
+			return clientset.AppsV1().DaemonSets(ns).Watch(ctx, opts)
+		},
+		list: func(ctx context.Context, clientset *kubernetes.Clientset, ns string, opts metav1.ListOptions) (runtime.Object, error) {
+			return clientset.AppsV1().DaemonSets(ns).List(ctx, opts)
+		},
+		row: func(obj runtime.Object) (interface{}, bool) {
+			ds, ok := obj.(*v1apps.DaemonSet)
+			if !ok {
+				return nil, false
+			}
+			return NewDaemonSetRow(*ds), true
+		},
+	},
+	"Job": {
+		watch: func(ctx context.Context, clientset *kubernetes.Clientset, ns string, opts metav1.ListOptions) (watch.Interface, error) {
+			return clientset.BatchV1().Jobs(ns).Watch(ctx, opts)
+		},
+		list: func(ctx context.Context, clientset *kubernetes.Clientset, ns string, opts metav1.ListOptions) (runtime.Object, error) {
+			return clientset.BatchV1().Jobs(ns).List(ctx, opts)
+		},
+		row: func(obj runtime.Object) (interface{}, bool) {
+			job, ok := obj.(*v1batch.Job)
+			if !ok {
+				return nil, false
+			}
+			return NewJobRow(*job), true
+		},
+	},
+	"CronJob": {
+		watch: func(ctx context.Context, clientset *kubernetes.Clientset, ns string, opts metav1.ListOptions) (watch.Interface, error) {
+			return clientset.BatchV1().CronJobs(ns).Watch(ctx, opts)
+		},
+		list: func(ctx context.Context, clientset *kubernetes.Clientset, ns string, opts metav1.ListOptions) (runtime.Object, error) {
+			return clientset.BatchV1().CronJobs(ns).List(ctx, opts)
+		},
+		row: func(obj runtime.Object) (interface{}, bool) {
+			cronJob, ok := obj.(*v1batch.CronJob)
+			if !ok {
+				return nil, false
+			}
+			return NewCronJobRow(*cronJob), true
+		},
+	},
+	"Pod": {
+		watch: func(ctx context.Context, clientset *kubernetes.Clientset, ns string, opts metav1.ListOptions) (watch.Interface, error) {
+			return clientset.CoreV1().Pods(ns).Watch(ctx, opts)
+		},
+		list: func(ctx context.Context, clientset *kubernetes.Clientset, ns string, opts metav1.ListOptions) (runtime.Object, error) {
+			return clientset.CoreV1().Pods(ns).List(ctx, opts)
+		},
+		row: func(obj runtime.Object) (interface{}, bool) {
+			pod, ok := obj.(*v1core.Pod)
+			if !ok {
+				return nil, false
+			}
+			return NewPodRow(*pod), true
+		},
+	},
+	"ReplicationController": {
+		watch: func(ctx context.Context, clientset *kubernetes.Clientset, ns string, opts metav1.ListOptions) (watch.Interface, error) {
+			return clientset.CoreV1().ReplicationControllers(ns).Watch(ctx, opts)
+		},
+		list: func(ctx context.Context, clientset *kubernetes.Clientset, ns string, opts metav1.ListOptions) (runtime.Object, error) {
+			return clientset.CoreV1().ReplicationControllers(ns).List(ctx, opts)
+		},
+		row: func(obj runtime.Object) (interface{}, bool) {
+			rc, ok := obj.(*v1core.ReplicationController)
+			if !ok {
+				return nil, false
+			}
+			return NewReplicationControllerRow(*rc), true
+		},
+	},
+}
+
+func (h *WatchK8sResourcesHandler) ServeHTTP(c echo.Context) error {
+	kind, ok := watchKinds[h.Kind]
+	if !ok {
+		logger.Warnf("Unsupported kind %s when calling WatchK8sResourcesHandler", h.Kind)
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	clientset, errMsg, err := GetClientSet(h.ID, h.Name, "WatchK8sResourcesHandler")
+	if err != nil {
+		logger.Warnf("%s: %v", errMsg, err)
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	ctx := c.Request().Context()
+	// EventSource sends the id of the last received event when it reconnects
+	resourceVersion := h.ResourceVersion
+	if lastEventID := c.Request().Header.Get("Last-Event-ID"); lastEventID != "" {
+		resourceVersion = lastEventID
+	}
+
+	watchOptions := func() metav1.ListOptions {
+		return metav1.ListOptions{
+			LabelSelector:       h.Selector,
+			ResourceVersion:     resourceVersion,
+			AllowWatchBookmarks: true,
+		}
+	}
+	// resync lists the objects again and sends them as one resync event, the watch continues from the version of the list
+	resync := func() error {
+		list, err := kind.list(ctx, clientset, h.NS, metav1.ListOptions{LabelSelector: h.Selector})
+		if err != nil {
+			return err
+		}
+		listMeta, err := meta.ListAccessor(list)
+		if err != nil {
+			return err
+		}
+		objects, err := meta.ExtractList(list)
+		if err != nil {
+			return err
+		}
+		resync := WatchResync{ResourceVersion: listMeta.GetResourceVersion(), Items: make([]WatchEvent, 0, len(objects))}
+		for _, object := range objects {
+			accessor, err := meta.Accessor(object)
+			if err != nil {
+				continue
+			}
+			row, ok := kind.row(object)
+			if !ok {
+				continue
+			}
+			resync.Items = append(resync.Items, WatchEvent{
+				Type:            string(watch.Added),
+				ResourceVersion: accessor.GetResourceVersion(),
+				Namespace:       accessor.GetNamespace(),
+				Name:            accessor.GetName(),
+				UID:             string(accessor.GetUID()),
+				Row:             row,
+			})
+		}
+		resourceVersion = resync.ResourceVersion
+		return writeSSE(c, "resync", resourceVersion, resync)
+	}
+	isExpired := func(err error) bool {
+		return apierrors.IsResourceExpired(err) || apierrors.IsGone(err)
+	}
+
+	watcher, err := kind.watch(ctx, clientset, h.NS, watchOptions())
+	needsResync := false
+	if err != nil {
+		if !isExpired(err) {
+			logger.Warnf("Failed to start watching %s when calling WatchK8sResourcesHandler: %v", h.Kind, err)
+			if apierrors.IsBadRequest(err) {
+				return c.NoContent(http.StatusBadRequest)
+			}
+			return c.NoContent(http.StatusInternalServerError)
+		}
+		// The version the client resumes from is too old, it gets the current list instead
+		watcher, needsResync = watch.NewEmptyWatch(), true
+	}
+	defer func() {
+		watcher.Stop()
+	}()
+
+	startSSE(c)
+
+	heartbeat := time.NewTicker(WatchHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	backoff := time.Duration(0)
+	watchStarted := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			// Client has disconnected
+			return nil
+		case <-heartbeat.C:
+			if err := writeSSE(c, "heartbeat", "", map[string]string{"resource_version": resourceVersion}); err != nil {
+				return nil
+			}
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				// The API server closes watches after a timeout, continue from the last seen version. Watches which
+				// close right away, e.g. on an expired version, are not restarted in a tight loop.
+				if time.Since(watchStarted) < WatchRestartMaxBackoff {
+					backoff = min(max(2*backoff, WatchRestartBackoff), WatchRestartMaxBackoff)
+				} else {
+					backoff = 0
+				}
+				if backoff > 0 {
+					timer := time.NewTimer(backoff)
+					select {
+					case <-ctx.Done():
+						timer.Stop()
+						return nil
+					case <-timer.C:
+					}
+				}
+
+				// A watch without a version would replay every object as ADDED, a list is sent as a resync instead
+				if needsResync || resourceVersion == "" {
+					if err := resync(); err != nil {
+						if ctx.Err() == nil {
+							logger.Warnf("Failed to list %s when calling WatchK8sResourcesHandler: %v", h.Kind, err)
+							_ = writeSSE(c, "error", "", map[string]string{"message": err.Error()})
+						}
+						return nil
+					}
+					needsResync = false
+				}
+				watchStarted = time.Now()
+				watcher, err = kind.watch(ctx, clientset, h.NS, watchOptions())
+				if err != nil {
+					if isExpired(err) {
+						watcher, needsResync = watch.NewEmptyWatch(), true
+						continue
+					}
+					if ctx.Err() == nil {
+						logger.Warnf("Failed to restart watching %s when calling WatchK8sResourcesHandler: %v", h.Kind, err)
+						_ = writeSSE(c, "error", "", map[string]string{"message": err.Error()})
+					}
+					return nil
+				}
+				continue
+			}
+
+			switch event.Type {
+			case watch.Error:
+				status := apierrors.FromObject(event.Object)
+				if !isExpired(status) {
+					_ = writeSSE(c, "error", "", map[string]string{"message": status.Error()})
+					return nil
+				}
+				// The version is too old, the watch is restarted from a fresh list once it closes
+				watcher.Stop()
+				needsResync = true
+			case watch.Bookmark:
+				if accessor, err := meta.Accessor(event.Object); err == nil {
+					resourceVersion = accessor.GetResourceVersion()
+				}
+			case watch.Added, watch.Modified, watch.Deleted:
+				accessor, err := meta.Accessor(event.Object)
+				if err != nil {
+					continue
+				}
+				resourceVersion = accessor.GetResourceVersion()
+				row, ok := kind.row(event.Object)
+				if !ok {
+					continue
+				}
+				if err := writeSSE(c, "", resourceVersion, WatchEvent{
+					Type:            string(event.Type),
+					ResourceVersion: resourceVersion,
+					Namespace:       accessor.GetNamespace(),
+					Name:            accessor.GetName(),
+					UID:             string(accessor.GetUID()),
+					Row:             row,
+				}); err != nil {
+					return nil
+				}
+			}
+		}
+	}
+}
+
+func writeSSE(c echo.Context, event, id string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		logger.Warnf("Failed to marshal server-sent event: %v", err)
+		return err
+	}
+	if event != "" {
+		if _, err := fmt.Fprintf(c.Response(), "event: %s\n", event); err != nil {
+			return err
+		}
+	}
+	if id != "" {
+		if _, err := fmt.Fprintf(c.Response(), "id: %s\n", id); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintf(c.Response(), "data: %s\n\n", data); err != nil {
+		return err
+	}
+	c.Response().Flush()
+	return nil
+}